@@ -16,7 +16,11 @@ package awsxrayexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/xray"
 	"go.opentelemetry.io/collector/component"
@@ -45,7 +49,7 @@ func newTracesExporter(
 	if err != nil {
 		return nil, err
 	}
-	xrayClient := newXRay(logger, awsConfig, set.BuildInfo, session)
+	xrayClient := newXRay(logger, awsConfig, set.BuildInfo, session, config.(*Config).Compression)
 	return exporterhelper.NewTracesExporter(
 		config,
 		set,
@@ -53,38 +57,48 @@ func newTracesExporter(
 			var err error
 			logger.Debug("TracesExporter", typeLog, nameLog, zap.Int("#spans", td.SpanCount()))
 			documents := make([]*string, 0, td.SpanCount())
+			segmentIDs := make([]string, 0, td.SpanCount())
 			for i := 0; i < td.ResourceSpans().Len(); i++ {
 				rspans := td.ResourceSpans().At(i)
 				resource := rspans.Resource()
 				for j := 0; j < rspans.InstrumentationLibrarySpans().Len(); j++ {
 					spans := rspans.InstrumentationLibrarySpans().At(j).Spans()
 					for k := 0; k < spans.Len(); k++ {
-						document, localErr := translator.MakeSegmentDocumentString(logger, spans.At(k), resource,
-							config.(*Config).IndexedAttributes, config.(*Config).IndexAllAttributes)
+						segment, localErr := translator.MakeSegment(logger, spans.At(k), resource,
+							config.(*Config).IndexedAttributes, config.(*Config).IndexAllAttributes,
+							config.(*Config).TraceIDConversionMode == TraceIDConversionModeRewriteEpoch)
 						if localErr != nil {
-							logger.Debug("Error translating span.", zap.Error(localErr))
+							reason := rejectReasonOther
+							if errors.Is(localErr, translator.ErrInvalidTraceID) {
+								reason = rejectReasonInvalidTraceID
+							}
+							recordSegmentRejected(ctx, reason, 1)
+							logger.Debug("Error translating span.", zap.String("id", spans.At(k).SpanID().HexString()),
+								zap.String("reason", reason), zap.Error(localErr))
+							continue
+						}
+						document, applied, localErr := translator.MakeTruncatedSegmentDocumentString(segment,
+							config.(*Config).Truncation.Policies, config.(*Config).Truncation.MaxStringLength)
+						for _, policy := range applied {
+							recordSegmentTruncated(ctx, policy)
+						}
+						if localErr != nil {
+							reason := rejectReasonOther
+							if errors.Is(localErr, translator.ErrSegmentTooLarge) {
+								reason = rejectReasonOversized
+							}
+							recordSegmentRejected(ctx, reason, 1)
+							logger.Debug("Error serializing segment.", zap.String("id", *segment.ID),
+								zap.String("reason", reason), zap.Error(localErr))
 							continue
 						}
 						documents = append(documents, &document)
+						segmentIDs = append(segmentIDs, *segment.ID)
 					}
 				}
 			}
-			for offset := 0; offset < len(documents); offset += maxSegmentsPerPut {
-				nextOffset := offset + maxSegmentsPerPut
-				if nextOffset > td.SpanCount() {
-					nextOffset = td.SpanCount()
-				}
-				input := xray.PutTraceSegmentsInput{TraceSegmentDocuments: documents[offset:nextOffset]}
-				logger.Debug("request: " + input.String())
-				output, localErr := xrayClient.PutTraceSegments(&input)
-				if localErr != nil {
-					logger.Debug("response error", zap.Error(localErr))
-					err = wrapErrorIfBadRequest(&localErr) // record error
-				}
-				if output != nil {
-					logger.Debug("response: " + output.String())
-				}
-				if err != nil {
+			for _, b := range makeBatches(documents, config.(*Config).MaxRequestSize) {
+				if err = putSegments(ctx, logger, &xrayClient, documents[b.start:b.end], segmentIDs[b.start:b.end]); err != nil {
 					break
 				}
 			}
@@ -97,6 +111,96 @@ func newTracesExporter(
 	)
 }
 
+// batch is the range of documents sent in a single PutTraceSegments request.
+type batch struct {
+	start, end int
+}
+
+// makeBatches splits documents in consecutive batches of at most maxSegmentsPerPut documents whose
+// total size does not exceed maxRequestSize.
+func makeBatches(documents []*string, maxRequestSize int) []batch {
+	var batches []batch
+	current, size := batch{}, 0
+	for i, document := range documents {
+		if current.end > current.start && (current.end-current.start == maxSegmentsPerPut || size+len(*document) > maxRequestSize) {
+			batches = append(batches, current)
+			current, size = batch{start: i, end: i}, 0
+		}
+		current.end++
+		size += len(*document)
+	}
+	if current.end > current.start {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// putSegments sends the documents to X-Ray. A batch rejected for its size is split in halves which
+// are sent separately, and a single document rejected for its size is dropped.
+func putSegments(ctx context.Context, logger *zap.Logger, client segmentsClient, documents []*string, segmentIDs []string) error {
+	input := xray.PutTraceSegmentsInput{TraceSegmentDocuments: documents}
+	logger.Debug("request: " + input.String())
+	output, err := client.PutTraceSegments(&input)
+	if err != nil {
+		logger.Debug("response error", zap.Error(err))
+		if isRequestTooLarge(err) {
+			if len(documents) == 1 {
+				recordSegmentRejected(ctx, rejectReasonOversized, 1)
+				logger.Debug("Segment rejected by X-Ray.", zap.String("id", segmentIDs[0]),
+					zap.String("reason", rejectReasonOversized), zap.Error(err))
+				return nil
+			}
+			half := len(documents) / 2
+			if err = putSegments(ctx, logger, client, documents[:half], segmentIDs[:half]); err != nil {
+				return err
+			}
+			return putSegments(ctx, logger, client, documents[half:], segmentIDs[half:])
+		}
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == xray.ErrCodeThrottledException {
+			recordSegmentRejected(ctx, rejectReasonThrottled, int64(len(documents)))
+			logger.Debug("Segments throttled.", zap.Strings("ids", segmentIDs))
+		}
+		return wrapErrorIfBadRequest(&err)
+	}
+	if output != nil {
+		logger.Debug("response: " + output.String())
+		recordUnprocessedSegments(ctx, logger, output.UnprocessedTraceSegments)
+	}
+	return nil
+}
+
+func isRequestTooLarge(err error) bool {
+	reqErr, ok := err.(awserr.RequestFailure)
+	return ok && reqErr.StatusCode() == http.StatusRequestEntityTooLarge
+}
+
+// recordUnprocessedSegments records the segments rejected by the X-Ray backend, by reason.
+func recordUnprocessedSegments(ctx context.Context, logger *zap.Logger, unprocessed []*xray.UnprocessedTraceSegment) {
+	for _, segment := range unprocessed {
+		reason := unprocessedSegmentReason(aws.StringValue(segment.ErrorCode))
+		recordSegmentRejected(ctx, reason, 1)
+		logger.Debug("Segment rejected by X-Ray.", zap.String("id", aws.StringValue(segment.Id)),
+			zap.String("reason", reason), zap.String("error_code", aws.StringValue(segment.ErrorCode)),
+			zap.String("message", aws.StringValue(segment.Message)))
+	}
+}
+
+// unprocessedSegmentReason maps the error code of an unprocessed segment to a rejection reason.
+func unprocessedSegmentReason(errorCode string) string {
+	code := strings.ToLower(errorCode)
+	switch {
+	case strings.Contains(code, "throttl"):
+		return rejectReasonThrottled
+	case strings.Contains(code, "expired"), strings.Contains(code, "tooold"):
+		return rejectReasonExpired
+	case strings.Contains(code, "toolarge"), strings.Contains(code, "size"):
+		return rejectReasonOversized
+	case strings.Contains(code, "traceid"):
+		return rejectReasonInvalidTraceID
+	}
+	return rejectReasonOther
+}
+
 func wrapErrorIfBadRequest(err *error) error {
 	_, ok := (*err).(awserr.RequestFailure)
 	if ok && (*err).(awserr.RequestFailure).StatusCode() < 500 {
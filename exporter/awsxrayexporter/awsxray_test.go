@@ -19,15 +19,22 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/xray"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/model/pdata"
 	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
 )
@@ -42,6 +49,94 @@ func TestTraceExport(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestTraceExportRecordsInvalidTraceID(t *testing.T) {
+	traceExporter := initializeTracesExporter()
+	ctx := context.Background()
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(pdata.NewTraceID([16]byte{0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11}))
+	span.SetSpanID(newSegmentID())
+
+	before := rejectedSegments(t, rejectReasonInvalidTraceID)
+	assert.NoError(t, traceExporter.ConsumeTraces(ctx, td))
+	assert.Equal(t, before+1, rejectedSegments(t, rejectReasonInvalidTraceID))
+}
+
+func TestUnprocessedSegmentReason(t *testing.T) {
+	assert.Equal(t, rejectReasonThrottled, unprocessedSegmentReason("ThrottledException"))
+	assert.Equal(t, rejectReasonExpired, unprocessedSegmentReason("SegmentExpired"))
+	assert.Equal(t, rejectReasonOversized, unprocessedSegmentReason("SegmentTooLarge"))
+	assert.Equal(t, rejectReasonInvalidTraceID, unprocessedSegmentReason("InvalidTraceId"))
+	assert.Equal(t, rejectReasonOther, unprocessedSegmentReason("InternalFailure"))
+}
+
+func TestMakeBatches(t *testing.T) {
+	documents := make([]*string, 0, 120)
+	for i := 0; i < 120; i++ {
+		document := strings.Repeat("a", 10)
+		documents = append(documents, &document)
+	}
+	assert.Equal(t, []batch{{0, 50}, {50, 100}, {100, 120}}, makeBatches(documents, 1000))
+	assert.Equal(t, []batch{{0, 30}, {30, 60}, {60, 90}, {90, 120}}, makeBatches(documents, 300))
+
+	large := strings.Repeat("b", 500)
+	assert.Equal(t, []batch{{0, 1}, {1, 2}, {2, 3}}, makeBatches([]*string{&large, documents[0], &large}, 300))
+	assert.Empty(t, makeBatches(nil, 300))
+}
+
+type fakeSegmentsClient struct {
+	maxDocuments int
+	requests     [][]*string
+}
+
+func (c *fakeSegmentsClient) PutTraceSegments(input *xray.PutTraceSegmentsInput) (*xray.PutTraceSegmentsOutput, error) {
+	c.requests = append(c.requests, input.TraceSegmentDocuments)
+	if len(input.TraceSegmentDocuments) > c.maxDocuments {
+		return nil, awserr.NewRequestFailure(awserr.New("RequestEntityTooLarge", "too large", nil), http.StatusRequestEntityTooLarge, "")
+	}
+	return &xray.PutTraceSegmentsOutput{}, nil
+}
+
+func TestPutSegmentsSplitsRejectedBatches(t *testing.T) {
+	documents := make([]*string, 0, 5)
+	for i := 0; i < 5; i++ {
+		document := fmt.Sprintf("segment%d", i)
+		documents = append(documents, &document)
+	}
+	ids := []string{"0", "1", "2", "3", "4"}
+	client := &fakeSegmentsClient{maxDocuments: 2}
+
+	require.NoError(t, putSegments(context.Background(), zap.NewNop(), client, documents, ids))
+
+	var sizes []int
+	for _, request := range client.requests {
+		sizes = append(sizes, len(request))
+	}
+	assert.Equal(t, []int{5, 2, 3, 1, 2}, sizes)
+}
+
+func TestPutSegmentsDropsOversizedSegment(t *testing.T) {
+	document := "segment"
+	client := &fakeSegmentsClient{maxDocuments: 0}
+
+	before := rejectedSegments(t, rejectReasonOversized)
+	require.NoError(t, putSegments(context.Background(), zap.NewNop(), client, []*string{&document}, []string{"0"}))
+	assert.Equal(t, before+1, rejectedSegments(t, rejectReasonOversized))
+}
+
+func rejectedSegments(t *testing.T, reason string) int64 {
+	rows, err := view.RetrieveData(mSegmentsRejected.Name())
+	require.NoError(t, err)
+	for _, row := range rows {
+		for _, tg := range row.Tags {
+			if tg.Key == tagReasonKey && tg.Value == reason {
+				return int64(row.Data.(*view.SumData).Value)
+			}
+		}
+	}
+	return 0
+}
+
 func BenchmarkForTracesExporter(b *testing.B) {
 	traceExporter := initializeTracesExporter()
 	for i := 0; i < b.N; i++ {
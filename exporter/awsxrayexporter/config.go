@@ -15,11 +15,31 @@
 package awsxrayexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter/internal/translator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
 )
 
+// Supported values of TraceIDConversionMode.
+const (
+	// TraceIDConversionModeNone drops spans whose trace ID was not generated by the X-Ray ID generator.
+	TraceIDConversionModeNone = "none"
+	// TraceIDConversionModeRewriteEpoch rewrites the first 4 bytes of such trace IDs to a valid epoch.
+	TraceIDConversionModeRewriteEpoch = "rewrite_epoch"
+)
+
+// Supported values of Compression.
+const (
+	// CompressionNone sends PutTraceSegments request bodies uncompressed.
+	CompressionNone = "none"
+	// CompressionGzip gzips PutTraceSegments request bodies.
+	CompressionGzip = "gzip"
+)
+
 // Config defines configuration for AWS X-Ray exporter.
 type Config struct {
 	config.ExporterSettings `mapstructure:",squash"`
@@ -33,4 +53,58 @@ type Config struct {
 	// Set to true to convert all OpenTelemetry attributes to X-Ray annotation (indexed) ignoring the IndexedAttributes option.
 	// Default value: false
 	IndexAllAttributes bool `mapstructure:"index_all_attributes"`
+	// TraceIDConversionMode controls how spans whose trace IDs were not generated by the X-Ray ID generator,
+	// e.g. by W3C-instrumented applications, are handled. With "none" they are dropped because X-Ray rejects
+	// their timestamp prefix. With "rewrite_epoch" the first 4 bytes are replaced with a valid epoch and the
+	// original trace ID is recorded in the "otel_original_trace_id" annotation.
+	// Default value: none
+	TraceIDConversionMode string `mapstructure:"trace_id_conversion_mode"`
+	// Truncation configures how segments exceeding the 64KB X-Ray document limit are shrunk.
+	Truncation TruncationSettings `mapstructure:"truncation"`
+	// MaxRequestSize is the maximum total size in bytes of the segment documents sent in a single
+	// PutTraceSegments request. Batches rejected by X-Ray for their size are split and resent.
+	// Default value: 1048576
+	MaxRequestSize int `mapstructure:"max_request_size"`
+	// Compression sets the compression of PutTraceSegments request bodies, either "none" or "gzip".
+	// Default value: none
+	Compression string `mapstructure:"compression"`
+}
+
+// TruncationSettings defines the policies applied to segments exceeding the X-Ray document size limit.
+type TruncationSettings struct {
+	// Policies lists the truncation policies applied, in order, until the segment fits: "drop_metadata",
+	// "truncate_strings" and "drop_subsegments". Segments still exceeding the limit are dropped.
+	Policies []string `mapstructure:"policies"`
+	// MaxStringLength is the length strings are truncated to by the "truncate_strings" policy.
+	// Default value: 1024
+	MaxStringLength int `mapstructure:"max_string_length"`
+}
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	switch cfg.TraceIDConversionMode {
+	case TraceIDConversionModeNone, TraceIDConversionModeRewriteEpoch:
+	default:
+		return fmt.Errorf("unsupported trace_id_conversion_mode %q", cfg.TraceIDConversionMode)
+	}
+	if cfg.MaxRequestSize < translator.MaxSegmentDocumentSize {
+		return fmt.Errorf("max_request_size must be at least %d", translator.MaxSegmentDocumentSize)
+	}
+	switch cfg.Compression {
+	case CompressionNone, CompressionGzip:
+	default:
+		return fmt.Errorf("unsupported compression %q", cfg.Compression)
+	}
+	for _, policy := range cfg.Truncation.Policies {
+		switch policy {
+		case translator.TruncationPolicyDropMetadata, translator.TruncationPolicyDropSubsegments:
+		case translator.TruncationPolicyTruncateStrings:
+			if cfg.Truncation.MaxStringLength <= 0 {
+				return errors.New("truncation max_string_length must be positive")
+			}
+		default:
+			return fmt.Errorf("unsupported truncation policy %q", policy)
+		}
+	}
+	return nil
 }
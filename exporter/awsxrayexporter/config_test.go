@@ -59,7 +59,44 @@ func TestLoadConfig(t *testing.T) {
 				ResourceARN:           "arn:aws:ec2:us-east1:123456789:instance/i-293hiuhe0u",
 				RoleARN:               "arn:aws:iam::123456789:role/monitoring-EKS-NodeInstanceRole",
 			},
-			IndexedAttributes:  []string{"indexed_attr_0", "indexed_attr_1"},
-			IndexAllAttributes: false,
+			IndexedAttributes:     []string{"indexed_attr_0", "indexed_attr_1"},
+			IndexAllAttributes:    false,
+			TraceIDConversionMode: TraceIDConversionModeRewriteEpoch,
+			Truncation: TruncationSettings{
+				Policies:        []string{"drop_metadata", "truncate_strings"},
+				MaxStringLength: 256,
+			},
+			MaxRequestSize: 512 * 1024,
+			Compression:    CompressionGzip,
 		})
 }
+
+func TestValidateTraceIDConversionMode(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.TraceIDConversionMode = "random"
+	assert.EqualError(t, cfg.Validate(), `unsupported trace_id_conversion_mode "random"`)
+}
+
+func TestValidateTruncationPolicies(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Truncation.Policies = []string{"drop_metadata", "truncate_strings", "drop_subsegments"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Truncation.MaxStringLength = 0
+	assert.EqualError(t, cfg.Validate(), "truncation max_string_length must be positive")
+
+	cfg.Truncation.Policies = []string{"drop_everything"}
+	assert.EqualError(t, cfg.Validate(), `unsupported truncation policy "drop_everything"`)
+}
+
+func TestValidateBatching(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxRequestSize = 1024
+	assert.EqualError(t, cfg.Validate(), "max_request_size must be at least 65536")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Compression = "zstd"
+	assert.EqualError(t, cfg.Validate(), `unsupported compression "zstd"`)
+}
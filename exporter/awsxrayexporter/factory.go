@@ -16,7 +16,9 @@ package awsxrayexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"sync"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -27,10 +29,19 @@ import (
 const (
 	// The value of "type" key in configuration.
 	typeStr = "awsxray"
+
+	defaultMaxStringLength = 1024
+	defaultMaxRequestSize  = 1024 * 1024
 )
 
+var once sync.Once
+
 // NewFactory creates a factory for AWS-Xray exporter.
 func NewFactory() component.ExporterFactory {
+	once.Do(func() {
+		_ = view.Register(MetricViews()...)
+	})
+
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
@@ -39,8 +50,14 @@ func NewFactory() component.ExporterFactory {
 
 func createDefaultConfig() config.Exporter {
 	return &Config{
-		ExporterSettings:   config.NewExporterSettings(config.NewComponentID(typeStr)),
-		AWSSessionSettings: awsutil.CreateDefaultSessionConfig(),
+		ExporterSettings:      config.NewExporterSettings(config.NewComponentID(typeStr)),
+		AWSSessionSettings:    awsutil.CreateDefaultSessionConfig(),
+		TraceIDConversionMode: TraceIDConversionModeNone,
+		Truncation: TruncationSettings{
+			MaxStringLength: defaultMaxStringLength,
+		},
+		MaxRequestSize: defaultMaxRequestSize,
+		Compression:    CompressionNone,
 	}
 }
 
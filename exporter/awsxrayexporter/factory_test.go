@@ -45,6 +45,12 @@ func TestCreateDefaultConfig(t *testing.T) {
 			ResourceARN:           "",
 			RoleARN:               "",
 		},
+		TraceIDConversionMode: TraceIDConversionModeNone,
+		Truncation: TruncationSettings{
+			MaxStringLength: 1024,
+		},
+		MaxRequestSize: 1024 * 1024,
+		Compression:    "none",
 	}, "failed to create default config")
 	assert.NoError(t, configtest.CheckConfigStruct(cfg))
 }
@@ -17,6 +17,7 @@ package translator // import "github.com/open-telemetry/opentelemetry-collector-
 import (
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/url"
@@ -59,6 +60,12 @@ const (
 const (
 	traceIDLength    = 35 // fixed length of aws trace id
 	identifierOffset = 11 // offset of identifier within traceID
+
+	// originalTraceIDAnnotationKey is the annotation recording the OpenTelemetry trace ID
+	// of spans whose trace ID had to be rewritten to be accepted by X-Ray.
+	originalTraceIDAnnotationKey = "otel_original_trace_id"
+
+	secondsPerDay = 60 * 60 * 24
 )
 
 var (
@@ -66,11 +73,15 @@ var (
 )
 
 // MakeSegmentDocumentString converts an OpenTelemetry Span to an X-Ray Segment and then serialzies to JSON
-func MakeSegmentDocumentString(logger *zap.Logger, span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool) (string, error) {
-	segment, err := MakeSegment(logger, span, resource, indexedAttrs, indexAllAttrs)
+func MakeSegmentDocumentString(logger *zap.Logger, span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool, rewriteInvalidTraceIDs bool) (string, error) {
+	segment, err := MakeSegment(logger, span, resource, indexedAttrs, indexAllAttrs, rewriteInvalidTraceIDs)
 	if err != nil {
 		return "", err
 	}
+	return encodeSegment(segment)
+}
+
+func encodeSegment(segment *awsxray.Segment) (string, error) {
 	w := writers.borrow()
 	if err := w.Encode(*segment); err != nil {
 		return "", err
@@ -80,8 +91,9 @@ func MakeSegmentDocumentString(logger *zap.Logger, span pdata.Span, resource pda
 	return jsonStr, nil
 }
 
-// MakeSegment converts an OpenTelemetry Span to an X-Ray Segment
-func MakeSegment(logger *zap.Logger, span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool) (*awsxray.Segment, error) {
+// MakeSegment converts an OpenTelemetry Span to an X-Ray Segment. When rewriteInvalidTraceIDs is set,
+// trace IDs that were not generated by the X-Ray ID generator are rewritten instead of rejected.
+func MakeSegment(logger *zap.Logger, span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool, rewriteInvalidTraceIDs bool) (*awsxray.Segment, error) {
 	var segmentType string
 
 	storeResource := true
@@ -93,9 +105,14 @@ func MakeSegment(logger *zap.Logger, span pdata.Span, resource pdata.Resource, i
 	}
 
 	// convert trace id
+	var originalTraceID string
 	traceID, err := convertToAmazonTraceID(span.TraceID())
 	if err != nil {
-		return nil, err
+		if !rewriteInvalidTraceIDs {
+			return nil, err
+		}
+		traceID = rewriteToAmazonTraceID(span.TraceID(), span.StartTimestamp())
+		originalTraceID = span.TraceID().HexString()
 	}
 
 	var (
@@ -188,6 +205,13 @@ func MakeSegment(logger *zap.Logger, span pdata.Span, resource pdata.Resource, i
 		namespace = "remote"
 	}
 
+	if originalTraceID != "" {
+		if annotations == nil {
+			annotations = map[string]interface{}{}
+		}
+		annotations[originalTraceIDAnnotationKey] = originalTraceID
+	}
+
 	return &awsxray.Segment{
 		ID:          awsxray.String(span.SpanID().HexString()),
 		TraceID:     awsxray.String(traceID),
@@ -267,6 +291,9 @@ func determineAwsOrigin(resource pdata.Resource) string {
 	return ""
 }
 
+// ErrInvalidTraceID is returned when a span's trace ID cannot be converted to the X-Ray format.
+var ErrInvalidTraceID = errors.New("invalid xray traceid")
+
 // convertToAmazonTraceID converts a trace ID to the Amazon format.
 //
 // A trace ID unique identifier that connects all segments and subsegments
@@ -302,7 +329,7 @@ func convertToAmazonTraceID(traceID pdata.TraceID) (string, error) {
 	//
 	// In that case, we return invalid traceid error
 	if delta := epochNow - epoch; delta > maxAge || delta < -maxSkew {
-		return "", fmt.Errorf("invalid xray traceid: %s", traceID.HexString())
+		return "", fmt.Errorf("%w: %s", ErrInvalidTraceID, traceID.HexString())
 	}
 
 	binary.BigEndian.PutUint32(b[0:4], uint32(epoch))
@@ -316,6 +343,41 @@ func convertToAmazonTraceID(traceID pdata.TraceID) (string, error) {
 	return string(content[0:traceIDLength]), nil
 }
 
+// rewriteToAmazonTraceID converts a trace ID whose first 4 bytes are not a valid X-Ray
+// epoch to the Amazon format by replacing the epoch with the start of the UTC day the
+// span started in. Using the day rather than the exact start time keeps all spans of
+// a trace, which usually start within seconds of each other, under the same X-Ray
+// trace ID. Spans with a start time outside of the accepted range use the current day.
+func rewriteToAmazonTraceID(traceID pdata.TraceID, startTime pdata.Timestamp) string {
+	const (
+		maxAge  = 60 * 60 * 24 * 28
+		maxSkew = 60 * 5
+	)
+
+	var (
+		content      = [traceIDLength]byte{}
+		epochNow     = time.Now().Unix()
+		traceIDBytes = traceID.Bytes()
+		epoch        = int64(startTime) / int64(time.Second)
+		b            = [4]byte{}
+	)
+
+	if delta := epochNow - epoch; delta > maxAge || delta < -maxSkew {
+		epoch = epochNow
+	}
+	epoch -= epoch % secondsPerDay
+
+	binary.BigEndian.PutUint32(b[0:4], uint32(epoch))
+
+	content[0] = '1'
+	content[1] = '-'
+	hex.Encode(content[2:10], b[0:4])
+	content[10] = '-'
+	hex.Encode(content[identifierOffset:], traceIDBytes[4:16])
+
+	return string(content[0:traceIDLength])
+}
+
 func timestampToFloatSeconds(ts pdata.Timestamp) float64 {
 	return float64(ts) / float64(time.Second)
 }
@@ -16,6 +16,7 @@ package translator
 
 import (
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -60,13 +61,13 @@ func TestClientSpanWithRpcAwsSdkClientAttributes(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 	assert.Equal(t, "DynamoDB", *segment.Name)
 	assert.Equal(t, conventions.AttributeCloudProviderAWS, *segment.Namespace)
 	assert.Equal(t, "GetItem", *segment.AWS.Operation)
 	assert.Equal(t, "subsegment", *segment.Type)
 
-	jsonStr, err := MakeSegmentDocumentString(zap.L(), span, resource, nil, false)
+	jsonStr, err := MakeSegmentDocumentString(zap.L(), span, resource, nil, false, false)
 
 	assert.NotNil(t, jsonStr)
 	assert.Nil(t, err)
@@ -93,13 +94,13 @@ func TestClientSpanWithLegacyAwsSdkClientAttributes(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 	assert.Equal(t, "DynamoDB", *segment.Name)
 	assert.Equal(t, conventions.AttributeCloudProviderAWS, *segment.Namespace)
 	assert.Equal(t, "GetItem", *segment.AWS.Operation)
 	assert.Equal(t, "subsegment", *segment.Type)
 
-	jsonStr, err := MakeSegmentDocumentString(zap.L(), span, resource, nil, false)
+	jsonStr, err := MakeSegmentDocumentString(zap.L(), span, resource, nil, false, false)
 
 	assert.NotNil(t, jsonStr)
 	assert.Nil(t, err)
@@ -125,7 +126,7 @@ func TestClientSpanWithPeerService(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, 0, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 	assert.Equal(t, "cats-table", *segment.Name)
 }
 
@@ -148,7 +149,7 @@ func TestServerSpanWithInternalServerError(t *testing.T) {
 	timeEvents := constructTimedEventsWithSentMessageEvent(span.StartTimestamp())
 	timeEvents.CopyTo(span.Events())
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.Cause)
@@ -175,7 +176,7 @@ func TestServerSpanWithThrottle(t *testing.T) {
 	timeEvents := constructTimedEventsWithSentMessageEvent(span.StartTimestamp())
 	timeEvents.CopyTo(span.Events())
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.Cause)
@@ -191,7 +192,7 @@ func TestServerSpanNoParentId(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeOk, "OK", nil)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 
 	assert.Empty(t, segment.ParentID)
 }
@@ -206,7 +207,7 @@ func TestSpanNoParentId(t *testing.T) {
 	span.SetStartTimestamp(pdata.NewTimestampFromTime(time.Now()))
 	span.SetEndTimestamp(pdata.NewTimestampFromTime(time.Now().Add(10)))
 	resource := pdata.NewResource()
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 
 	assert.Empty(t, segment.ParentID)
 	assert.Nil(t, segment.Type)
@@ -222,7 +223,7 @@ func TestSpanWithNoStatus(t *testing.T) {
 	span.SetEndTimestamp(pdata.NewTimestampFromTime(time.Now().Add(10)))
 
 	resource := pdata.NewResource()
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 	assert.NotNil(t, segment)
 }
 
@@ -242,7 +243,7 @@ func TestClientSpanWithDbComponent(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, pdata.StatusCodeUnset, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.SQL)
@@ -283,7 +284,7 @@ func TestClientSpanWithHttpHost(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, pdata.StatusCodeUnset, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, "foo.com", *segment.Name)
@@ -302,7 +303,7 @@ func TestClientSpanWithoutHttpHost(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, pdata.StatusCodeUnset, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, "bar.com", *segment.Name)
@@ -322,7 +323,7 @@ func TestClientSpanWithRpcHost(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, pdata.StatusCodeUnset, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, "com.foo.AnimalService", *segment.Name)
@@ -344,9 +345,53 @@ func TestSpanWithInvalidTraceId(t *testing.T) {
 	traceID[0] = 0x11
 	span.SetTraceID(pdata.NewTraceID(traceID))
 
-	_, err := MakeSegmentDocumentString(zap.L(), span, resource, nil, false)
+	_, err := MakeSegmentDocumentString(zap.L(), span, resource, nil, false, false)
 
-	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, ErrInvalidTraceID)
+}
+
+func TestSpanWithInvalidTraceIdRewritten(t *testing.T) {
+	spanName := "platformapi.widgets.searchWidgets"
+	attributes := make(map[string]interface{})
+	resource := constructDefaultResource()
+	span := constructServerSpan(pdata.InvalidSpanID(), spanName, pdata.StatusCodeUnset, "OK", attributes)
+	traceID := span.TraceID().Bytes()
+	traceID[0] = 0x11
+	span.SetTraceID(pdata.NewTraceID(traceID))
+
+	segment, err := MakeSegment(zap.L(), span, resource, nil, false, true)
+
+	assert.NoError(t, err)
+	startEpoch := int64(span.StartTimestamp()) / int64(time.Second)
+	expectedEpoch := fmt.Sprintf("%08x", startEpoch-startEpoch%secondsPerDay)
+	assert.Equal(t, "1-"+expectedEpoch+"-"+span.TraceID().HexString()[8:], *segment.TraceID)
+	assert.Equal(t, span.TraceID().HexString(), segment.Annotations[originalTraceIDAnnotationKey])
+
+	// All spans of the trace are rewritten to the same X-Ray trace ID.
+	span.SetStartTimestamp(span.StartTimestamp() + pdata.Timestamp(time.Second))
+	other, err := MakeSegment(zap.L(), span, resource, nil, false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, *segment.TraceID, *other.TraceID)
+}
+
+func TestRewriteTraceIdWithOutOfRangeStartTime(t *testing.T) {
+	traceID := newTraceID()
+
+	amazonTraceID := rewriteToAmazonTraceID(traceID, pdata.NewTimestampFromTime(time.Unix(0, 0)))
+
+	epochNow := time.Now().Unix()
+	expectedEpoch := fmt.Sprintf("%08x", epochNow-epochNow%secondsPerDay)
+	assert.Equal(t, "1-"+expectedEpoch+"-"+traceID.HexString()[8:], amazonTraceID)
+	_, err := convertToAmazonTraceID(pdata.NewTraceID(rewrittenTraceIDBytes(t, amazonTraceID)))
+	assert.NoError(t, err)
+}
+
+func rewrittenTraceIDBytes(t *testing.T, amazonTraceID string) [16]byte {
+	var b [16]byte
+	decoded, err := hex.DecodeString(strings.ReplaceAll(amazonTraceID[2:], "-", ""))
+	assert.NoError(t, err)
+	copy(b[:], decoded)
+	return b
 }
 
 func TestSpanWithExpiredTraceId(t *testing.T) {
@@ -392,7 +437,7 @@ func TestServerSpanWithNilAttributes(t *testing.T) {
 	timeEvents.CopyTo(span.Events())
 	pdata.NewAttributeMap().CopyTo(span.Attributes())
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.NotNil(t, segment.Cause)
@@ -409,7 +454,7 @@ func TestSpanWithAttributesDefaultNotIndexed(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, 0, len(segment.Annotations))
@@ -436,7 +481,7 @@ func TestSpanWithResourceNotStoredIfSubsegment(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructClientSpan(parentSpanID, spanName, pdata.StatusCodeError, "ERROR", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, nil, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, nil, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, 0, len(segment.Annotations))
@@ -459,7 +504,7 @@ func TestSpanWithAttributesPartlyIndexed(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{"attr1@1", "not_exist"}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{"attr1@1", "not_exist"}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, 1, len(segment.Annotations))
@@ -476,7 +521,7 @@ func TestSpanWithAttributesAllIndexed(t *testing.T) {
 	resource := constructDefaultResource()
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeOk, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{"attr1@1", "not_exist"}, true)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{"attr1@1", "not_exist"}, true, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, "val1", segment.Annotations["attr1_1"])
@@ -497,7 +542,7 @@ func TestResourceAttributesCanBeIndexed(t *testing.T) {
 		"otel.resource.bool.key",
 		"otel.resource.map.key",
 		"otel.resource.array.key",
-	}, false)
+	}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, 4, len(segment.Annotations))
@@ -529,7 +574,7 @@ func TestResourceAttributesNotIndexedIfSubsegment(t *testing.T) {
 		"otel.resource.bool.key",
 		"otel.resource.map.key",
 		"otel.resource.array.key",
-	}, false)
+	}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Empty(t, segment.Annotations)
@@ -547,7 +592,7 @@ func TestOriginNotAws(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Nil(t, segment.Origin)
@@ -565,7 +610,7 @@ func TestOriginEc2(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginEC2, *segment.Origin)
@@ -584,7 +629,7 @@ func TestOriginEcs(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginECS, *segment.Origin)
@@ -604,7 +649,7 @@ func TestOriginEcsEc2(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginECSEC2, *segment.Origin)
@@ -624,7 +669,7 @@ func TestOriginEcsFargate(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginECSFargate, *segment.Origin)
@@ -644,7 +689,7 @@ func TestOriginEb(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginEB, *segment.Origin)
@@ -676,7 +721,7 @@ func TestOriginEks(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginEKS, *segment.Origin)
@@ -694,7 +739,7 @@ func TestOriginAppRunner(t *testing.T) {
 	parentSpanID := newSegmentID()
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginAppRunner, *segment.Origin)
@@ -710,7 +755,7 @@ func TestOriginBlank(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Nil(t, segment.Origin)
@@ -731,7 +776,7 @@ func TestOriginPrefersInfraService(t *testing.T) {
 	attrs.CopyTo(resource.Attributes())
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Equal(t, OriginEC2, *segment.Origin)
@@ -764,7 +809,7 @@ func TestFilteredAttributesMetadata(t *testing.T) {
 	span := constructServerSpan(parentSpanID, spanName, pdata.StatusCodeError, "OK", attributes)
 	attrs.CopyTo(span.Attributes())
 
-	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false)
+	segment, _ := MakeSegment(zap.L(), span, resource, []string{}, false, false)
 
 	assert.NotNil(t, segment)
 	assert.Nil(t, segment.Metadata["default"]["null_value"])
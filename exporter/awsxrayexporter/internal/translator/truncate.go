@@ -0,0 +1,176 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter/internal/translator"
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+// Truncation policies that can be applied to segments exceeding MaxSegmentDocumentSize.
+const (
+	// TruncationPolicyDropMetadata removes the metadata of the segment and its embedded subsegments.
+	TruncationPolicyDropMetadata = "drop_metadata"
+	// TruncationPolicyTruncateStrings shortens long free-form strings such as metadata values,
+	// annotations, URLs, SQL queries and exception messages.
+	TruncationPolicyTruncateStrings = "truncate_strings"
+	// TruncationPolicyDropSubsegments removes the embedded subsegments of the segment.
+	TruncationPolicyDropSubsegments = "drop_subsegments"
+)
+
+// MaxSegmentDocumentSize is the maximum size in bytes of a segment document accepted by X-Ray.
+const MaxSegmentDocumentSize = 64 * 1024
+
+// ErrSegmentTooLarge is returned when a segment still exceeds MaxSegmentDocumentSize after all
+// configured truncation policies were applied.
+var ErrSegmentTooLarge = errors.New("segment document exceeds the X-Ray size limit")
+
+// MakeTruncatedSegmentDocumentString serializes an X-Ray Segment to JSON. While the document exceeds
+// MaxSegmentDocumentSize the given truncation policies are applied to the segment, in order. It returns
+// the policies that were applied, which is also reported when ErrSegmentTooLarge is returned.
+func MakeTruncatedSegmentDocumentString(segment *awsxray.Segment, policies []string, maxStringLength int) (string, []string, error) {
+	document, err := encodeSegment(segment)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var applied []string
+	for _, policy := range policies {
+		if len(document) <= MaxSegmentDocumentSize {
+			return document, applied, nil
+		}
+		if !applyTruncationPolicy(segment, policy, maxStringLength) {
+			continue
+		}
+		applied = append(applied, policy)
+		if document, err = encodeSegment(segment); err != nil {
+			return "", applied, err
+		}
+	}
+
+	if len(document) > MaxSegmentDocumentSize {
+		return "", applied, ErrSegmentTooLarge
+	}
+	return document, applied, nil
+}
+
+// applyTruncationPolicy applies the policy to the segment and reports whether it changed anything.
+func applyTruncationPolicy(segment *awsxray.Segment, policy string, maxStringLength int) bool {
+	switch policy {
+	case TruncationPolicyDropMetadata:
+		return dropMetadata(segment)
+	case TruncationPolicyTruncateStrings:
+		return truncateSegmentStrings(segment, maxStringLength)
+	case TruncationPolicyDropSubsegments:
+		if len(segment.Subsegments) == 0 {
+			return false
+		}
+		segment.Subsegments = nil
+		return true
+	}
+	return false
+}
+
+func dropMetadata(segment *awsxray.Segment) bool {
+	changed := len(segment.Metadata) > 0
+	segment.Metadata = nil
+	for i := range segment.Subsegments {
+		if dropMetadata(&segment.Subsegments[i]) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func truncateSegmentStrings(segment *awsxray.Segment, maxLength int) bool {
+	t := stringTruncator{maxLength: maxLength}
+
+	for key, value := range segment.Annotations {
+		segment.Annotations[key] = t.value(value)
+	}
+	for namespace, values := range segment.Metadata {
+		for key, value := range values {
+			segment.Metadata[namespace][key] = t.value(value)
+		}
+	}
+	if segment.HTTP != nil && segment.HTTP.Request != nil {
+		t.pointer(segment.HTTP.Request.URL)
+		t.pointer(segment.HTTP.Request.UserAgent)
+	}
+	if segment.SQL != nil {
+		t.pointer(segment.SQL.SanitizedQuery)
+		t.pointer(segment.SQL.URL)
+		t.pointer(segment.SQL.ConnectionString)
+	}
+	if segment.Cause != nil {
+		for i := range segment.Cause.Exceptions {
+			exception := &segment.Cause.Exceptions[i]
+			t.pointer(exception.Message)
+			for j := range exception.Stack {
+				t.pointer(exception.Stack[j].Path)
+				t.pointer(exception.Stack[j].Label)
+			}
+		}
+	}
+	for i := range segment.Subsegments {
+		if truncateSegmentStrings(&segment.Subsegments[i], maxLength) {
+			t.changed = true
+		}
+	}
+	return t.changed
+}
+
+// stringTruncator shortens strings to at most maxLength bytes, without splitting UTF-8 characters,
+// and records whether it changed any.
+type stringTruncator struct {
+	maxLength int
+	changed   bool
+}
+
+func (t *stringTruncator) string(s string) string {
+	if len(s) <= t.maxLength {
+		return s
+	}
+	t.changed = true
+	end := t.maxLength
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
+
+func (t *stringTruncator) pointer(s *string) {
+	if s != nil {
+		*s = t.string(*s)
+	}
+}
+
+func (t *stringTruncator) value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return t.string(v)
+	case map[string]interface{}:
+		for key, nested := range v {
+			v[key] = t.value(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = t.value(nested)
+		}
+	}
+	return value
+}
@@ -0,0 +1,108 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+func newTestSegment() *awsxray.Segment {
+	return &awsxray.Segment{
+		Name:      awsxray.String("test"),
+		ID:        awsxray.String("5fa1c7e6a9d6d3fc"),
+		TraceID:   awsxray.String("1-5f84c7a1-e7d1852db8c4fd35d88bf49a"),
+		StartTime: func() *float64 { f := 1602537377.0; return &f }(),
+	}
+}
+
+func TestTruncationNotNeeded(t *testing.T) {
+	segment := newTestSegment()
+	segment.Metadata = map[string]map[string]interface{}{"default": {"key": "value"}}
+
+	document, applied, err := MakeTruncatedSegmentDocumentString(segment, []string{TruncationPolicyDropMetadata}, 10)
+
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.Contains(t, document, `"key":"value"`)
+}
+
+func TestTruncationDropMetadataFirst(t *testing.T) {
+	segment := newTestSegment()
+	segment.Metadata = map[string]map[string]interface{}{"default": {"big": strings.Repeat("a", MaxSegmentDocumentSize)}}
+	segment.Annotations = map[string]interface{}{"small": "value"}
+
+	document, applied, err := MakeTruncatedSegmentDocumentString(segment,
+		[]string{TruncationPolicyDropMetadata, TruncationPolicyTruncateStrings}, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{TruncationPolicyDropMetadata}, applied)
+	assert.NotContains(t, document, "metadata")
+	assert.Contains(t, document, `"small":"value"`)
+}
+
+func TestTruncationTruncateStrings(t *testing.T) {
+	segment := newTestSegment()
+	segment.Metadata = map[string]map[string]interface{}{"default": {
+		"nested": map[string]interface{}{"list": []interface{}{strings.Repeat("b", MaxSegmentDocumentSize)}},
+	}}
+	segment.SQL = &awsxray.SQLData{SanitizedQuery: awsxray.String(strings.Repeat("c", 100))}
+	segment.Cause = &awsxray.CauseData{CauseObject: awsxray.CauseObject{Exceptions: []awsxray.Exception{
+		{Message: awsxray.String(strings.Repeat("d", 100))},
+	}}}
+
+	document, applied, err := MakeTruncatedSegmentDocumentString(segment,
+		[]string{TruncationPolicyDropSubsegments, TruncationPolicyTruncateStrings}, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{TruncationPolicyTruncateStrings}, applied)
+	assert.Contains(t, document, `"list":["bbbbbbbbbb"]`)
+	assert.Equal(t, strings.Repeat("c", 10), *segment.SQL.SanitizedQuery)
+	assert.Equal(t, strings.Repeat("d", 10), *segment.Cause.Exceptions[0].Message)
+}
+
+func TestTruncationDoesNotSplitCharacters(t *testing.T) {
+	truncator := stringTruncator{maxLength: 3}
+	assert.Equal(t, "ab", truncator.string("abéé"))
+	assert.True(t, truncator.changed)
+}
+
+func TestTruncationDropSubsegments(t *testing.T) {
+	segment := newTestSegment()
+	child := newTestSegment()
+	child.Metadata = map[string]map[string]interface{}{"default": {"big": strings.Repeat("a", MaxSegmentDocumentSize)}}
+	segment.Subsegments = []awsxray.Segment{*child}
+
+	document, applied, err := MakeTruncatedSegmentDocumentString(segment, []string{TruncationPolicyDropSubsegments}, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{TruncationPolicyDropSubsegments}, applied)
+	assert.NotContains(t, document, "subsegments")
+}
+
+func TestTruncationStillTooLarge(t *testing.T) {
+	segment := newTestSegment()
+	segment.Metadata = map[string]map[string]interface{}{"default": {"big": strings.Repeat("a", MaxSegmentDocumentSize)}}
+
+	_, applied, err := MakeTruncatedSegmentDocumentString(segment, []string{TruncationPolicyDropSubsegments}, 10)
+
+	assert.ErrorIs(t, err, ErrSegmentTooLarge)
+	assert.Empty(t, applied)
+}
@@ -0,0 +1,68 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsxrayexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Reasons for which segments are rejected, either by the exporter or by the X-Ray backend.
+const (
+	rejectReasonInvalidTraceID = "invalid_trace_id"
+	rejectReasonOversized      = "oversized"
+	rejectReasonThrottled      = "throttled"
+	rejectReasonExpired        = "expired"
+	rejectReasonOther          = "other"
+)
+
+var (
+	tagPolicyKey = tag.MustNewKey("policy")
+	tagReasonKey = tag.MustNewKey("reason")
+
+	mSegmentsTruncated = stats.Int64("awsxray_segments_truncated", "Number of segments a truncation policy was applied to", stats.UnitDimensionless)
+	mSegmentsRejected  = stats.Int64("awsxray_segments_rejected", "Number of segments dropped by the exporter or rejected by X-Ray", stats.UnitDimensionless)
+)
+
+// MetricViews return the metrics views according to given telemetry level.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mSegmentsTruncated.Name(),
+			Measure:     mSegmentsTruncated,
+			Description: mSegmentsTruncated.Description(),
+			TagKeys:     []tag.Key{tagPolicyKey},
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mSegmentsRejected.Name(),
+			Measure:     mSegmentsRejected,
+			Description: mSegmentsRejected.Description(),
+			TagKeys:     []tag.Key{tagReasonKey},
+			Aggregation: view.Sum(),
+		},
+	}
+}
+
+func recordSegmentTruncated(ctx context.Context, policy string) {
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tagPolicyKey, policy)}, mSegmentsTruncated.M(1))
+}
+
+func recordSegmentRejected(ctx context.Context, reason string, count int64) {
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tagReasonKey, reason)}, mSegmentsRejected.M(count))
+}
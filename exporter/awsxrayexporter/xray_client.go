@@ -16,11 +16,15 @@
 package awsxrayexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter"
 
 import (
+	"bytes"
+	"compress/gzip"
+	"io"
 	"os"
 	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/xray"
@@ -30,6 +34,11 @@ import (
 
 var collectorDistribution = "opentelemetry-collector-contrib"
 
+// segmentsClient is the subset of the X-Ray API used to send segments.
+type segmentsClient interface {
+	PutTraceSegments(input *xray.PutTraceSegmentsInput) (*xray.PutTraceSegmentsOutput, error)
+}
+
 // xrayClient represents X-Ray client.
 type xrayClient struct {
 	xRay *xray.XRay
@@ -46,7 +55,7 @@ func (c *xrayClient) PutTelemetryRecords(input *xray.PutTelemetryRecordsInput) (
 }
 
 // newXRay creates a new instance of the XRay client with a aws configuration and session .
-func newXRay(logger *zap.Logger, awsConfig *aws.Config, buildInfo component.BuildInfo, s *session.Session) xrayClient {
+func newXRay(logger *zap.Logger, awsConfig *aws.Config, buildInfo component.BuildInfo, s *session.Session, compression string) xrayClient {
 	x := xray.New(s, awsConfig)
 	logger.Debug("Using Endpoint: %s", zap.String("endpoint", x.Endpoint))
 
@@ -57,6 +66,10 @@ func newXRay(logger *zap.Logger, awsConfig *aws.Config, buildInfo component.Buil
 
 	x.Handlers.Build.PushFrontNamed(newCollectorUserAgentHandler(buildInfo))
 
+	if compression == CompressionGzip {
+		x.Handlers.Build.PushBackNamed(newGzipRequestHandler())
+	}
+
 	x.Handlers.Sign.PushFrontNamed(request.NamedHandler{
 		Name: "tracing.TimestampHandler",
 		Fn: func(r *request.Request) {
@@ -76,3 +89,29 @@ func newCollectorUserAgentHandler(buildInfo component.BuildInfo) request.NamedHa
 		Fn:   request.MakeAddToUserAgentHandler(collectorDistribution, buildInfo.Version),
 	}
 }
+
+// newGzipRequestHandler compresses the serialized request body. It must run after the body is built
+// and before the request is signed.
+func newGzipRequestHandler() request.NamedHandler {
+	return request.NamedHandler{
+		Name: "otel.collector.GzipRequestHandler",
+		Fn: func(r *request.Request) {
+			body := r.GetBody()
+			if body == nil {
+				return
+			}
+			var buf bytes.Buffer
+			zw := gzip.NewWriter(&buf)
+			if _, err := io.Copy(zw, body); err != nil {
+				r.Error = awserr.New(request.ErrCodeSerialization, "failed to compress request body", err)
+				return
+			}
+			if err := zw.Close(); err != nil {
+				r.Error = awserr.New(request.ErrCodeSerialization, "failed to compress request body", err)
+				return
+			}
+			r.SetBufferBody(buf.Bytes())
+			r.HTTPRequest.Header.Set("Content-Encoding", "gzip")
+		},
+	}
+}
@@ -15,13 +15,17 @@
 package awsxrayexporter
 
 import (
+	"compress/gzip"
+	"io/ioutil"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client/metadata"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	awsxray "github.com/aws/aws-sdk-go/service/xray"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
 )
@@ -34,7 +38,7 @@ func TestUserAgent(t *testing.T) {
 	}
 
 	session, _ := session.NewSession()
-	xray := newXRay(logger, &aws.Config{}, buildInfo, session)
+	xray := newXRay(logger, &aws.Config{}, buildInfo, session, CompressionNone)
 	x := xray.xRay
 
 	req := request.New(aws.Config{}, metadata.ClientInfo{}, x.Handlers, nil, &request.Operation{
@@ -45,3 +49,19 @@ func TestUserAgent(t *testing.T) {
 	x.Handlers.Build.Run(req)
 	assert.Contains(t, req.HTTPRequest.UserAgent(), "opentelemetry-collector-contrib/1.0")
 }
+
+func TestGzipRequest(t *testing.T) {
+	session, _ := session.NewSession()
+	xray := newXRay(zap.NewNop(), &aws.Config{}, component.BuildInfo{}, session, CompressionGzip)
+	document := "segment"
+	req, _ := xray.xRay.PutTraceSegmentsRequest(&awsxray.PutTraceSegmentsInput{TraceSegmentDocuments: []*string{&document}})
+
+	require.NoError(t, req.Build())
+	assert.Equal(t, "gzip", req.HTTPRequest.Header.Get("Content-Encoding"))
+
+	zr, err := gzip.NewReader(req.GetBody())
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(zr)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"TraceSegmentDocuments":["segment"]}`, string(body))
+}
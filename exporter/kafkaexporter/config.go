@@ -15,8 +15,11 @@
 package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/Shopify/sarama"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -32,7 +35,8 @@ type Config struct {
 	Brokers []string `mapstructure:"brokers"`
 	// Kafka protocol version
 	ProtocolVersion string `mapstructure:"protocol_version"`
-	// The name of the kafka topic to export to (default otlp_spans for traces, otlp_metrics for metrics)
+	// The name of the kafka topic to export to (default otlp_spans for traces, otlp_metrics for metrics).
+	// Placeholders like {k8s.namespace.name} are replaced with the value of the resource attribute.
 	Topic string `mapstructure:"topic"`
 
 	// Encoding of messages (default "otlp_proto")
@@ -67,6 +71,15 @@ type Metadata struct {
 type Producer struct {
 	// Maximum message bytes the producer will accept to produce.
 	MaxMessageBytes int `mapstructure:"max_message_bytes"`
+
+	// RequiredAcks is the number of acknowledgements required from the brokers: 0 for none,
+	// 1 for the leader only and -1 for all in-sync replicas (default 1).
+	RequiredAcks int `mapstructure:"required_acks"`
+
+	// Idempotent enables idempotent production, which guarantees that retried messages
+	// are written exactly once per partition. It requires required_acks -1 and
+	// protocol_version 0.11.0 or later.
+	Idempotent bool `mapstructure:"idempotent"`
 }
 
 // MetadataRetry defines retry configuration for Metadata.
@@ -83,5 +96,24 @@ var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
+	switch sarama.RequiredAcks(cfg.Producer.RequiredAcks) {
+	case sarama.NoResponse, sarama.WaitForLocal, sarama.WaitForAll:
+	default:
+		return fmt.Errorf("producer.required_acks must be one of -1, 0 or 1, got %d", cfg.Producer.RequiredAcks)
+	}
+	if cfg.Producer.Idempotent {
+		if sarama.RequiredAcks(cfg.Producer.RequiredAcks) != sarama.WaitForAll {
+			return errors.New("producer.idempotent requires producer.required_acks to be -1")
+		}
+		if cfg.ProtocolVersion != "" {
+			version, err := sarama.ParseKafkaVersion(cfg.ProtocolVersion)
+			if err != nil {
+				return err
+			}
+			if !version.IsAtLeast(sarama.V0_11_0_0) {
+				return errors.New("producer.idempotent requires protocol_version 0.11.0 or later")
+			}
+		}
+	}
 	return nil
 }
@@ -72,6 +72,33 @@ func TestLoadConfig(t *testing.T) {
 		},
 		Producer: Producer{
 			MaxMessageBytes: 10000000,
+			RequiredAcks:    -1,
+			Idempotent:      true,
 		},
 	}, c)
 }
+
+func TestValidateProducer(t *testing.T) {
+	tests := []struct {
+		name     string
+		producer Producer
+		version  string
+		err      string
+	}{
+		{name: "default", producer: Producer{RequiredAcks: 1}},
+		{name: "idempotent", producer: Producer{RequiredAcks: -1, Idempotent: true}, version: "2.0.0"},
+		{name: "invalid acks", producer: Producer{RequiredAcks: 2}, err: "producer.required_acks must be one of -1, 0 or 1, got 2"},
+		{name: "idempotent acks", producer: Producer{RequiredAcks: 1, Idempotent: true}, err: "producer.idempotent requires producer.required_acks to be -1"},
+		{name: "idempotent version", producer: Producer{RequiredAcks: -1, Idempotent: true}, version: "0.10.2.0", err: "producer.idempotent requires protocol_version 0.11.0 or later"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &Config{Producer: test.producer, ProtocolVersion: test.version}
+			if test.err == "" {
+				assert.NoError(t, cfg.Validate())
+			} else {
+				assert.EqualError(t, cfg.Validate(), test.err)
+			}
+		})
+	}
+}
@@ -39,6 +39,8 @@ const (
 	defaultMetadataFull = true
 	// default max.message.bytes for the producer
 	defaultProducerMaxMessageBytes = 1000000
+	// wait only for the local commit to succeed before responding
+	defaultProducerRequiredAcks = 1
 )
 
 // FactoryOption applies changes to kafkaExporterFactory.
@@ -91,6 +93,7 @@ func createDefaultConfig() config.Exporter {
 		},
 		Producer: Producer{
 			MaxMessageBytes: defaultProducerMaxMessageBytes,
+			RequiredAcks:    defaultProducerRequiredAcks,
 		},
 	}
 }
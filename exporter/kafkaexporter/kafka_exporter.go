@@ -30,7 +30,7 @@ var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 // kafkaTracesProducer uses sarama to produce trace messages to Kafka.
 type kafkaTracesProducer struct {
 	producer  sarama.SyncProducer
-	topic     string
+	topic     topicTemplate
 	marshaler TracesMarshaler
 	logger    *zap.Logger
 }
@@ -45,11 +45,16 @@ func (ke kafkaErrors) Error() string {
 }
 
 func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td pdata.Traces) error {
-	messages, err := e.marshaler.Marshal(td, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	topics, byTopic := e.topic.splitTraces(td)
+	var messages []*sarama.ProducerMessage
+	for _, topic := range topics {
+		topicMessages, err := e.marshaler.Marshal(byTopic[topic], topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		messages = append(messages, topicMessages...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		if value, ok := err.(sarama.ProducerErrors); ok {
 			if len(value) > 0 {
@@ -68,17 +73,22 @@ func (e *kafkaTracesProducer) Close(context.Context) error {
 // kafkaMetricsProducer uses sarama to produce metrics messages to kafka
 type kafkaMetricsProducer struct {
 	producer  sarama.SyncProducer
-	topic     string
+	topic     topicTemplate
 	marshaler MetricsMarshaler
 	logger    *zap.Logger
 }
 
 func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pdata.Metrics) error {
-	messages, err := e.marshaler.Marshal(md, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	topics, byTopic := e.topic.splitMetrics(md)
+	var messages []*sarama.ProducerMessage
+	for _, topic := range topics {
+		topicMessages, err := e.marshaler.Marshal(byTopic[topic], topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		messages = append(messages, topicMessages...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		if value, ok := err.(sarama.ProducerErrors); ok {
 			if len(value) > 0 {
@@ -97,17 +107,22 @@ func (e *kafkaMetricsProducer) Close(context.Context) error {
 // kafkaLogsProducer uses sarama to produce logs messages to kafka
 type kafkaLogsProducer struct {
 	producer  sarama.SyncProducer
-	topic     string
+	topic     topicTemplate
 	marshaler LogsMarshaler
 	logger    *zap.Logger
 }
 
 func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld pdata.Logs) error {
-	messages, err := e.marshaler.Marshal(ld, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	topics, byTopic := e.topic.splitLogs(ld)
+	var messages []*sarama.ProducerMessage
+	for _, topic := range topics {
+		topicMessages, err := e.marshaler.Marshal(byTopic[topic], topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		messages = append(messages, topicMessages...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		if value, ok := err.(sarama.ProducerErrors); ok {
 			if len(value) > 0 {
@@ -128,8 +143,12 @@ func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	// These setting are required by the sarama.SyncProducer implementation.
 	c.Producer.Return.Successes = true
 	c.Producer.Return.Errors = true
-	// Wait only the local commit to succeed before responding.
-	c.Producer.RequiredAcks = sarama.WaitForLocal
+	c.Producer.RequiredAcks = sarama.RequiredAcks(config.Producer.RequiredAcks)
+	if config.Producer.Idempotent {
+		// Idempotent production requires at most one in-flight request per broker connection.
+		c.Producer.Idempotent = true
+		c.Net.MaxOpenRequests = 1
+	}
 	// Because sarama does not accept a Context for every message, set the Timeout here.
 	c.Producer.Timeout = config.Timeout
 	c.Metadata.Full = config.Metadata.Full
@@ -165,7 +184,7 @@ func newMetricsExporter(config Config, set component.ExporterCreateSettings, mar
 
 	return &kafkaMetricsProducer{
 		producer:  producer,
-		topic:     config.Topic,
+		topic:     newTopicTemplate(config.Topic),
 		marshaler: marshaler,
 		logger:    set.Logger,
 	}, nil
@@ -184,7 +203,7 @@ func newTracesExporter(config Config, set component.ExporterCreateSettings, mars
 	}
 	return &kafkaTracesProducer{
 		producer:  producer,
-		topic:     config.Topic,
+		topic:     newTopicTemplate(config.Topic),
 		marshaler: marshaler,
 		logger:    set.Logger,
 	}, nil
@@ -202,7 +221,7 @@ func newLogsExporter(config Config, set component.ExporterCreateSettings, marsha
 
 	return &kafkaLogsProducer{
 		producer:  producer,
-		topic:     config.Topic,
+		topic:     newTopicTemplate(config.Topic),
 		marshaler: marshaler,
 		logger:    set.Logger,
 	}, nil
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// topicPlaceholder matches the resource attribute placeholders of a topic template, e.g. {k8s.namespace.name}.
+var topicPlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// topicTemplate is a topic name whose placeholders are resolved from resource attributes.
+type topicTemplate struct {
+	// topic is the configured topic name.
+	topic string
+	// segments holds the literal parts and attribute names of the topic, nil if it has no placeholders.
+	segments []topicSegment
+}
+
+type topicSegment struct {
+	literal   string
+	attribute string
+}
+
+func newTopicTemplate(topic string) topicTemplate {
+	matches := topicPlaceholder.FindAllStringSubmatchIndex(topic, -1)
+	if len(matches) == 0 {
+		return topicTemplate{topic: topic}
+	}
+	var segments []topicSegment
+	last := 0
+	for _, match := range matches {
+		if match[0] > last {
+			segments = append(segments, topicSegment{literal: topic[last:match[0]]})
+		}
+		segments = append(segments, topicSegment{attribute: topic[match[2]:match[3]]})
+		last = match[1]
+	}
+	if last < len(topic) {
+		segments = append(segments, topicSegment{literal: topic[last:]})
+	}
+	return topicTemplate{topic: topic, segments: segments}
+}
+
+// isStatic returns true if the topic does not depend on resource attributes.
+func (t topicTemplate) isStatic() bool {
+	return t.segments == nil
+}
+
+// resolve returns the topic for a resource. Placeholders of missing attributes resolve to an empty string.
+func (t topicTemplate) resolve(resource pdata.Resource) string {
+	if t.isStatic() {
+		return t.topic
+	}
+	var sb strings.Builder
+	for _, segment := range t.segments {
+		if segment.attribute == "" {
+			sb.WriteString(segment.literal)
+			continue
+		}
+		if value, ok := resource.Attributes().Get(segment.attribute); ok {
+			sb.WriteString(value.AsString())
+		}
+	}
+	return sb.String()
+}
+
+// splitTraces groups the resource spans of td by resolved topic.
+func (t topicTemplate) splitTraces(td pdata.Traces) ([]string, map[string]pdata.Traces) {
+	if t.isStatic() {
+		return []string{t.topic}, map[string]pdata.Traces{t.topic: td}
+	}
+	var topics []string
+	byTopic := map[string]pdata.Traces{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		topic := t.resolve(rs.Resource())
+		traces, ok := byTopic[topic]
+		if !ok {
+			traces = pdata.NewTraces()
+			byTopic[topic] = traces
+			topics = append(topics, topic)
+		}
+		rs.CopyTo(traces.ResourceSpans().AppendEmpty())
+	}
+	return topics, byTopic
+}
+
+// splitMetrics groups the resource metrics of md by resolved topic.
+func (t topicTemplate) splitMetrics(md pdata.Metrics) ([]string, map[string]pdata.Metrics) {
+	if t.isStatic() {
+		return []string{t.topic}, map[string]pdata.Metrics{t.topic: md}
+	}
+	var topics []string
+	byTopic := map[string]pdata.Metrics{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		topic := t.resolve(rm.Resource())
+		metrics, ok := byTopic[topic]
+		if !ok {
+			metrics = pdata.NewMetrics()
+			byTopic[topic] = metrics
+			topics = append(topics, topic)
+		}
+		rm.CopyTo(metrics.ResourceMetrics().AppendEmpty())
+	}
+	return topics, byTopic
+}
+
+// splitLogs groups the resource logs of ld by resolved topic.
+func (t topicTemplate) splitLogs(ld pdata.Logs) ([]string, map[string]pdata.Logs) {
+	if t.isStatic() {
+		return []string{t.topic}, map[string]pdata.Logs{t.topic: ld}
+	}
+	var topics []string
+	byTopic := map[string]pdata.Logs{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		topic := t.resolve(rl.Resource())
+		logs, ok := byTopic[topic]
+		if !ok {
+			logs = pdata.NewLogs()
+			byTopic[topic] = logs
+			topics = append(topics, topic)
+		}
+		rl.CopyTo(logs.ResourceLogs().AppendEmpty())
+	}
+	return topics, byTopic
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestTopicTemplateResolve(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("k8s.namespace.name", "prod")
+	resource.Attributes().InsertInt("shard", 3)
+
+	assert.Equal(t, "otlp_logs", newTopicTemplate("otlp_logs").resolve(resource))
+	assert.Equal(t, "otlp_logs_prod", newTopicTemplate("otlp_logs_{k8s.namespace.name}").resolve(resource))
+	assert.Equal(t, "prod-3-logs", newTopicTemplate("{k8s.namespace.name}-{shard}-logs").resolve(resource))
+	assert.Equal(t, "otlp_logs_", newTopicTemplate("otlp_logs_{missing}").resolve(resource))
+}
+
+func TestTopicTemplateSplitTraces(t *testing.T) {
+	td := pdata.NewTraces()
+	for _, namespace := range []string{"prod", "dev", "prod"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("k8s.namespace.name", namespace)
+		rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName(namespace)
+	}
+
+	topics, byTopic := newTopicTemplate("spans_{k8s.namespace.name}").splitTraces(td)
+	assert.Equal(t, []string{"spans_prod", "spans_dev"}, topics)
+	assert.Equal(t, 2, byTopic["spans_prod"].SpanCount())
+	assert.Equal(t, 1, byTopic["spans_dev"].SpanCount())
+
+	topics, byTopic = newTopicTemplate("spans").splitTraces(td)
+	assert.Equal(t, []string{"spans"}, topics)
+	assert.Equal(t, 3, byTopic["spans"].SpanCount())
+}
+
+func TestLogsPusher_topic_template(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	producer.ExpectSendMessageAndSucceed()
+	producer.ExpectSendMessageAndSucceed()
+	var sent []string
+
+	ld := pdata.NewLogs()
+	for _, namespace := range []string{"prod", "dev"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("k8s.namespace.name", namespace)
+		rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	}
+
+	p := kafkaLogsProducer{
+		producer:  &topicRecorder{SyncProducer: producer, topics: &sent},
+		topic:     newTopicTemplate("otlp_logs_{k8s.namespace.name}"),
+		marshaler: newPdataLogsMarshaler(otlp.NewProtobufLogsMarshaler(), defaultEncoding),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.logsDataPusher(context.Background(), ld))
+	assert.Equal(t, []string{"otlp_logs_prod", "otlp_logs_dev"}, sent)
+}
+
+// topicRecorder records the topics of the messages sent by the wrapped producer.
+type topicRecorder struct {
+	sarama.SyncProducer
+	topics *[]string
+}
+
+func (r *topicRecorder) SendMessages(msgs []*sarama.ProducerMessage) error {
+	for _, msg := range msgs {
+		*r.topics = append(*r.topics, msg.Topic)
+	}
+	return r.SyncProducer.SendMessages(msgs)
+}
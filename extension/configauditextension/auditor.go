@@ -0,0 +1,303 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauditextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/configauditextension"
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+// Auditor is the interface exposed by the config_audit extension. Components
+// that want their data audited look the extension up through
+// component.Host.GetExtensions and hand it the data they process. Auditing
+// never modifies the data.
+type Auditor interface {
+	// AuditTraces checks a sample of the resources in td.
+	AuditTraces(ctx context.Context, td pdata.Traces)
+	// AuditMetrics checks a sample of the resources in md.
+	AuditMetrics(ctx context.Context, md pdata.Metrics)
+	// AuditLogs checks a sample of the resources in ld.
+	AuditLogs(ctx context.Context, ld pdata.Logs)
+}
+
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+
+	ruleMissingResourceAttribute = "missing_resource_attribute"
+	ruleAttributeTypeMismatch    = "attribute_type_mismatch"
+)
+
+// Violation describes a single semantic convention violation.
+type Violation struct {
+	Time        time.Time `json:"time"`
+	Signal      string    `json:"signal"`
+	Rule        string    `json:"rule"`
+	Attribute   string    `json:"attribute"`
+	Expected    string    `json:"expected,omitempty"`
+	Actual      string    `json:"actual,omitempty"`
+	ServiceName string    `json:"service_name,omitempty"`
+}
+
+// defaultAttributeTypes holds the expected types of commonly used semantic
+// convention attributes.
+var defaultAttributeTypes = map[string]string{
+	conventions.AttributeServiceName:       attributeTypeString,
+	conventions.AttributeServiceNamespace:  attributeTypeString,
+	conventions.AttributeServiceInstanceID: attributeTypeString,
+	conventions.AttributeServiceVersion:    attributeTypeString,
+	conventions.AttributeHostName:          attributeTypeString,
+	conventions.AttributeK8SPodName:        attributeTypeString,
+	conventions.AttributeK8SNamespaceName:  attributeTypeString,
+	conventions.AttributeHTTPMethod:        attributeTypeString,
+	conventions.AttributeHTTPURL:           attributeTypeString,
+	conventions.AttributeHTTPRoute:         attributeTypeString,
+	conventions.AttributeHTTPStatusCode:    attributeTypeInt,
+	conventions.AttributeNetPeerIP:         attributeTypeString,
+	conventions.AttributeNetPeerPort:       attributeTypeInt,
+	conventions.AttributeNetHostPort:       attributeTypeInt,
+	conventions.AttributeDBSystem:          attributeTypeString,
+	conventions.AttributeRPCSystem:         attributeTypeString,
+	conventions.AttributeRPCGRPCStatusCode: attributeTypeInt,
+}
+
+// auditor performs the semantic convention checks and keeps a bounded list of
+// the most recent violations.
+type auditor struct {
+	samplingPercentage float64
+	requiredAttributes []string
+	attributeTypes     map[string]pdata.AttributeValueType
+
+	mu         sync.Mutex
+	violations []Violation
+	next       int
+	maxKept    int
+	random     func() float64
+}
+
+var _ Auditor = (*auditor)(nil)
+
+func newAuditor(cfg *Config) *auditor {
+	types := make(map[string]pdata.AttributeValueType, len(defaultAttributeTypes)+len(cfg.AttributeTypes))
+	for name, typ := range defaultAttributeTypes {
+		types[name] = toAttributeValueType(typ)
+	}
+	for name, typ := range cfg.AttributeTypes {
+		types[name] = toAttributeValueType(typ)
+	}
+	return &auditor{
+		samplingPercentage: cfg.SamplingPercentage,
+		requiredAttributes: cfg.RequiredResourceAttributes,
+		attributeTypes:     types,
+		maxKept:            cfg.MaxReportedViolations,
+		random:             rand.Float64,
+	}
+}
+
+func toAttributeValueType(typ string) pdata.AttributeValueType {
+	switch typ {
+	case attributeTypeInt:
+		return pdata.AttributeValueTypeInt
+	case attributeTypeDouble:
+		return pdata.AttributeValueTypeDouble
+	case attributeTypeBool:
+		return pdata.AttributeValueTypeBool
+	case attributeTypeArray:
+		return pdata.AttributeValueTypeArray
+	case attributeTypeMap:
+		return pdata.AttributeValueTypeMap
+	default:
+		return pdata.AttributeValueTypeString
+	}
+}
+
+func (a *auditor) AuditTraces(ctx context.Context, td pdata.Traces) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if !a.sampled() {
+			continue
+		}
+		c := a.newCheck(signalTraces, rs.Resource())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				c.checkTypes(span.Attributes())
+				events := span.Events()
+				for l := 0; l < events.Len(); l++ {
+					c.checkTypes(events.At(l).Attributes())
+				}
+			}
+		}
+		a.record(ctx, c)
+	}
+}
+
+func (a *auditor) AuditMetrics(ctx context.Context, md pdata.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if !a.sampled() {
+			continue
+		}
+		c := a.newCheck(signalMetrics, rm.Resource())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				c.checkMetric(metrics.At(k))
+			}
+		}
+		a.record(ctx, c)
+	}
+}
+
+func (a *auditor) AuditLogs(ctx context.Context, ld pdata.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if !a.sampled() {
+			continue
+		}
+		c := a.newCheck(signalLogs, rl.Resource())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				c.checkTypes(logs.At(k).Attributes())
+			}
+		}
+		a.record(ctx, c)
+	}
+}
+
+// Violations returns the most recent violations, oldest first.
+func (a *auditor) Violations() []Violation {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]Violation, 0, len(a.violations))
+	if len(a.violations) < a.maxKept {
+		return append(out, a.violations...)
+	}
+	out = append(out, a.violations[a.next:]...)
+	return append(out, a.violations[:a.next]...)
+}
+
+func (a *auditor) sampled() bool {
+	if a.samplingPercentage >= 100 {
+		return true
+	}
+	return a.random()*100 < a.samplingPercentage
+}
+
+func (a *auditor) newCheck(signal string, resource pdata.Resource) *check {
+	c := &check{auditor: a, signal: signal}
+	attrs := resource.Attributes()
+	if v, ok := attrs.Get(conventions.AttributeServiceName); ok {
+		c.serviceName = v.AsString()
+	}
+	for _, name := range a.requiredAttributes {
+		if _, ok := attrs.Get(name); !ok {
+			c.add(ruleMissingResourceAttribute, name, "", "")
+		}
+	}
+	c.checkTypes(attrs)
+	return c
+}
+
+func (a *auditor) record(ctx context.Context, c *check) {
+	recordAudited(ctx, c.signal)
+	if len(c.violations) == 0 {
+		return
+	}
+	for _, v := range c.violations {
+		recordViolation(ctx, v.Signal, v.Rule)
+	}
+	if a.maxKept == 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, v := range c.violations {
+		if len(a.violations) < a.maxKept {
+			a.violations = append(a.violations, v)
+			continue
+		}
+		a.violations[a.next] = v
+		a.next = (a.next + 1) % a.maxKept
+	}
+}
+
+// check collects the violations found in a single resource.
+type check struct {
+	auditor     *auditor
+	signal      string
+	serviceName string
+	violations  []Violation
+}
+
+func (c *check) add(rule, attribute, expected, actual string) {
+	c.violations = append(c.violations, Violation{
+		Time:        time.Now(),
+		Signal:      c.signal,
+		Rule:        rule,
+		Attribute:   attribute,
+		Expected:    expected,
+		Actual:      actual,
+		ServiceName: c.serviceName,
+	})
+}
+
+func (c *check) checkTypes(attrs pdata.AttributeMap) {
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		if expected, ok := c.auditor.attributeTypes[k]; ok && v.Type() != expected {
+			c.add(ruleAttributeTypeMismatch, k, expected.String(), v.Type().String())
+		}
+		return true
+	})
+}
+
+func (c *check) checkMetric(m pdata.Metric) {
+	switch m.DataType() {
+	case pdata.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			c.checkTypes(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			c.checkTypes(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			c.checkTypes(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			c.checkTypes(dps.At(i).Attributes())
+		}
+	}
+}
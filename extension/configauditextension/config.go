@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauditextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/configauditextension"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// Supported values for the attribute_types setting.
+const (
+	attributeTypeString = "string"
+	attributeTypeInt    = "int"
+	attributeTypeDouble = "double"
+	attributeTypeBool   = "bool"
+	attributeTypeArray  = "array"
+	attributeTypeMap    = "map"
+)
+
+var (
+	errNoEndpointProvided      = errors.New("bad config: endpoint must be specified")
+	errInvalidSamplingPercent  = errors.New("bad config: sampling_percentage must be between 0 and 100")
+	errInvalidPath             = errors.New("bad config: path must start with /")
+	errInvalidMaxViolations    = errors.New("bad config: max_reported_violations must not be negative")
+	errEmptyRequiredAttributes = errors.New("bad config: required_resource_attributes must not contain empty names")
+)
+
+// Config has the configuration for the config audit extension, which samples
+// pipeline data handed to it and reports semantic convention violations.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// TCPAddr is the address the debug report endpoint listens on.
+	// The default endpoint is "localhost:13135".
+	TCPAddr confignet.TCPAddr `mapstructure:",squash"`
+
+	// Path is the path the violation report is served on.
+	// The default path is "/debug/configaudit".
+	Path string `mapstructure:"path"`
+
+	// SamplingPercentage is the percentage of resources (ResourceSpans,
+	// ResourceMetrics, ResourceLogs) that are audited. The default is 10.
+	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+
+	// RequiredResourceAttributes lists the resource attributes that every
+	// audited resource must carry. The default is ["service.name"].
+	RequiredResourceAttributes []string `mapstructure:"required_resource_attributes"`
+
+	// AttributeTypes maps attribute names to the value type they are expected
+	// to have, one of "string", "int", "double", "bool", "array" or "map".
+	// Entries configured here are merged over the built-in semantic
+	// convention defaults.
+	AttributeTypes map[string]string `mapstructure:"attribute_types"`
+
+	// MaxReportedViolations is the number of most recent violations kept for
+	// the debug report. The default is 100.
+	MaxReportedViolations int `mapstructure:"max_reported_violations"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.TCPAddr.Endpoint == "" {
+		return errNoEndpointProvided
+	}
+	if !strings.HasPrefix(cfg.Path, "/") {
+		return errInvalidPath
+	}
+	if cfg.SamplingPercentage < 0 || cfg.SamplingPercentage > 100 {
+		return errInvalidSamplingPercent
+	}
+	if cfg.MaxReportedViolations < 0 {
+		return errInvalidMaxViolations
+	}
+	for _, name := range cfg.RequiredResourceAttributes {
+		if name == "" {
+			return errEmptyRequiredAttributes
+		}
+	}
+	for name, typ := range cfg.AttributeTypes {
+		switch typ {
+		case attributeTypeString, attributeTypeInt, attributeTypeDouble, attributeTypeBool, attributeTypeArray, attributeTypeMap:
+		default:
+			return fmt.Errorf("bad config: unsupported type %q for attribute %q", typ, name)
+		}
+	}
+	return nil
+}
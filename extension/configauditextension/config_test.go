@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauditextension
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Extensions[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	ext0 := cfg.Extensions[config.NewComponentID(typeStr)]
+	assert.Equal(t, factory.CreateDefaultConfig(), ext0)
+
+	ext1 := cfg.Extensions[config.NewComponentIDWithName(typeStr, "1")]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(config.NewComponentIDWithName(typeStr, "1")),
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "localhost:13136",
+			},
+			Path:                       "/audit",
+			SamplingPercentage:         50,
+			RequiredResourceAttributes: []string{"service.name", "deployment.environment"},
+			AttributeTypes: map[string]string{
+				"http.status_code": "string",
+				"team.id":          "int",
+			},
+			MaxReportedViolations: 10,
+		},
+		ext1)
+
+	assert.Equal(t, 1, len(cfg.Service.Extensions))
+	assert.Equal(t, config.NewComponentIDWithName(typeStr, "1"), cfg.Service.Extensions[0])
+}
+
+func TestLoadConfigError(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		configName  string
+		expectedErr error
+	}{
+		{
+			"missingendpoint",
+			errNoEndpointProvided,
+		},
+		{
+			"invalidpath",
+			errInvalidPath,
+		},
+		{
+			"invalidsampling",
+			errInvalidSamplingPercent,
+		},
+		{
+			"invalidmaxviolations",
+			errInvalidMaxViolations,
+		},
+		{
+			"emptyattribute",
+			errEmptyRequiredAttributes,
+		},
+	}
+	for _, tt := range tests {
+		factory := NewFactory()
+		factories.Extensions[typeStr] = factory
+		cfg, _ := configtest.LoadConfig(path.Join(".", "testdata", "config_bad.yaml"), factories)
+		extension := cfg.Extensions[config.NewComponentIDWithName(typeStr, tt.configName)]
+		err := extension.Validate()
+		require.ErrorIs(t, err, tt.expectedErr)
+	}
+}
+
+func TestValidateAttributeTypes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AttributeTypes = map[string]string{"http.status_code": "number"}
+	assert.EqualError(t, cfg.Validate(), `bad config: unsupported type "number" for attribute "http.status_code"`)
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauditextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/configauditextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+type configAuditExtension struct {
+	*auditor
+
+	config Config
+	logger *zap.Logger
+	server http.Server
+	stopCh chan struct{}
+}
+
+var _ Auditor = (*configAuditExtension)(nil)
+
+// report is the document served on the debug endpoint.
+type report struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (e *configAuditExtension) Start(_ context.Context, host component.Host) error {
+	e.logger.Info("Starting config_audit extension", zap.Any("config", e.config))
+
+	ln, err := e.config.TCPAddr.Listen()
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(e.config.Path, e.handler())
+	e.server.Handler = mux
+	e.stopCh = make(chan struct{})
+	go func() {
+		defer close(e.stopCh)
+
+		// The listener ownership goes to the server.
+		if errHTTP := e.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (e *configAuditExtension) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(report{Violations: e.Violations()}); err != nil {
+			e.logger.Warn("Failed to write config audit report", zap.Error(err))
+		}
+	})
+}
+
+func (e *configAuditExtension) Shutdown(context.Context) error {
+	err := e.server.Close()
+	if e.stopCh != nil {
+		<-e.stopCh
+	}
+	return err
+}
+
+func newConfigAuditExtension(config Config, logger *zap.Logger) *configAuditExtension {
+	return &configAuditExtension{
+		auditor: newAuditor(&config),
+		config:  config,
+		logger:  logger,
+		server:  http.Server{},
+	}
+}
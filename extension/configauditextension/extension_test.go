@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauditextension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testutil"
+)
+
+func newTestConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SamplingPercentage = 100
+	return cfg
+}
+
+func TestAuditTraces(t *testing.T) {
+	a := newAuditor(newTestConfig())
+
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().InsertString("http.status_code", "200")
+	span.Attributes().InsertInt("http.method", 1)
+	span.Events().AppendEmpty().Attributes().InsertString("net.peer.port", "80")
+
+	valid := td.ResourceSpans().AppendEmpty()
+	valid.Resource().Attributes().InsertString("service.name", "checkout")
+	valid.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().Attributes().InsertInt("http.status_code", 200)
+
+	a.AuditTraces(context.Background(), td)
+
+	violations := a.Violations()
+	require.Len(t, violations, 4)
+	assert.Equal(t, ruleMissingResourceAttribute, violations[0].Rule)
+	assert.Equal(t, "service.name", violations[0].Attribute)
+	assert.Equal(t, signalTraces, violations[0].Signal)
+	assert.Equal(t, ruleAttributeTypeMismatch, violations[1].Rule)
+	assert.Equal(t, "http.status_code", violations[1].Attribute)
+	assert.Equal(t, "INT", violations[1].Expected)
+	assert.Equal(t, "STRING", violations[1].Actual)
+	assert.Equal(t, "http.method", violations[2].Attribute)
+	assert.Equal(t, "net.peer.port", violations[3].Attribute)
+}
+
+func TestAuditMetricsAndLogs(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.AttributeTypes = map[string]string{"team.id": attributeTypeInt}
+	a := newAuditor(cfg)
+
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "api")
+	m := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetDataType(pdata.MetricDataTypeSum)
+	m.Sum().DataPoints().AppendEmpty().Attributes().InsertString("team.id", "a")
+	a.AuditMetrics(context.Background(), md)
+
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertInt("service.name", 1)
+	rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	a.AuditLogs(context.Background(), ld)
+
+	violations := a.Violations()
+	require.Len(t, violations, 2)
+	assert.Equal(t, signalMetrics, violations[0].Signal)
+	assert.Equal(t, "team.id", violations[0].Attribute)
+	assert.Equal(t, "api", violations[0].ServiceName)
+	assert.Equal(t, signalLogs, violations[1].Signal)
+	assert.Equal(t, "service.name", violations[1].Attribute)
+	assert.Equal(t, ruleAttributeTypeMismatch, violations[1].Rule)
+}
+
+func TestAuditSampling(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.SamplingPercentage = 50
+	a := newAuditor(cfg)
+	a.random = func() float64 { return 0.6 }
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	a.AuditTraces(context.Background(), td)
+	assert.Empty(t, a.Violations())
+
+	a.random = func() float64 { return 0.4 }
+	a.AuditTraces(context.Background(), td)
+	assert.Len(t, a.Violations(), 1)
+}
+
+func TestViolationsAreBounded(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.MaxReportedViolations = 2
+	cfg.RequiredResourceAttributes = []string{"a", "b", "c"}
+	a := newAuditor(cfg)
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	a.AuditLogs(context.Background(), ld)
+
+	violations := a.Violations()
+	require.Len(t, violations, 2)
+	assert.Equal(t, "b", violations[0].Attribute)
+	assert.Equal(t, "c", violations[1].Attribute)
+}
+
+func TestReportEndpoint(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.TCPAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	ext := newConfigAuditExtension(*cfg, zap.NewNop())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	ext.AuditTraces(context.Background(), td)
+
+	resp, err := http.Get("http://" + cfg.TCPAddr.Endpoint + defaultPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var r report
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&r))
+	require.Len(t, r.Violations, 1)
+	assert.Equal(t, "service.name", r.Violations[0].Attribute)
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauditextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/configauditextension"
+
+import (
+	"context"
+	"sync"
+
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/extension/extensionhelper"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "config_audit"
+
+	defaultEndpoint              = "localhost:13135"
+	defaultPath                  = "/debug/configaudit"
+	defaultSamplingPercentage    = 10
+	defaultMaxReportedViolations = 100
+)
+
+var once sync.Once
+
+// NewFactory creates a factory for the config audit extension.
+func NewFactory() component.ExtensionFactory {
+	once.Do(func() {
+		_ = view.Register(MetricViews()...)
+	})
+
+	return extensionhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		createExtension)
+}
+
+func createDefaultConfig() config.Extension {
+	return &Config{
+		ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: defaultEndpoint,
+		},
+		Path:                       defaultPath,
+		SamplingPercentage:         defaultSamplingPercentage,
+		RequiredResourceAttributes: []string{conventions.AttributeServiceName},
+		MaxReportedViolations:      defaultMaxReportedViolations,
+	}
+}
+
+func createExtension(_ context.Context, set component.ExtensionCreateSettings, cfg config.Extension) (component.Extension, error) {
+	config := cfg.(*Config)
+
+	return newConfigAuditExtension(*config, set.Logger), nil
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauditextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/configauditextension"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagSignalKey, _ = tag.NewKey("signal")
+	tagRuleKey, _   = tag.NewKey("rule")
+
+	mAudited    = stats.Int64("configaudit_resources_audited", "Number of resources that were audited", stats.UnitDimensionless)
+	mViolations = stats.Int64("configaudit_violations", "Number of semantic convention violations found", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to the config audit extension.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mAudited.Name(),
+			Measure:     mAudited,
+			Description: mAudited.Description(),
+			TagKeys:     []tag.Key{tagSignalKey},
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        mViolations.Name(),
+			Measure:     mViolations,
+			Description: mViolations.Description(),
+			TagKeys:     []tag.Key{tagSignalKey, tagRuleKey},
+			Aggregation: view.Sum(),
+		},
+	}
+}
+
+func recordAudited(ctx context.Context, signal string) {
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tagSignalKey, signal)}, mAudited.M(1))
+}
+
+func recordViolation(ctx context.Context, signal, rule string) {
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tagSignalKey, signal), tag.Upsert(tagRuleKey, rule)}, mViolations.M(1))
+}
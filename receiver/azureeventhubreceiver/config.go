@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-amqp-common-go/v3/conn"
+	"go.opentelemetry.io/collector/config"
+)
+
+// Supported values of StartPosition.
+const (
+	// StartPositionLatest consumes the events enqueued after the receiver started.
+	StartPositionLatest = "latest"
+	// StartPositionEarliest consumes all the events retained by the Event Hub.
+	StartPositionEarliest = "earliest"
+)
+
+var (
+	errMissingConnection    = errors.New("bad config: connection is required")
+	errMissingEntityPath    = errors.New("bad config: connection must contain the EntityPath of the Event Hub")
+	errIncompleteCheckpoint = errors.New("bad config: checkpoint requires account_name, account_key and container_name")
+	errUnknownStartPosition = errors.New("bad config: start_position must be latest or earliest")
+)
+
+// Config defines configuration for the Azure Event Hub receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+
+	// Connection is the Event Hub connection string, including the EntityPath of the Event Hub.
+	Connection string `mapstructure:"connection"`
+
+	// ConsumerGroup is the consumer group the receiver reads from (default $Default).
+	ConsumerGroup string `mapstructure:"consumer_group"`
+
+	// StartPosition sets where the receiver starts consuming partitions without checkpoint,
+	// either "latest" or "earliest" (default latest).
+	StartPosition string `mapstructure:"start_position"`
+
+	// Checkpoint configures the Azure Blob Storage container used to lease partitions and
+	// store checkpoints. Without it, every collector instance consumes all partitions and
+	// the position is lost on restart.
+	Checkpoint *CheckpointSettings `mapstructure:"checkpoint"`
+}
+
+// CheckpointSettings defines the Azure Blob Storage container used for checkpoints.
+type CheckpointSettings struct {
+	// AccountName is the name of the storage account.
+	AccountName string `mapstructure:"account_name"`
+	// AccountKey is the shared key of the storage account.
+	AccountKey string `mapstructure:"account_key"`
+	// ContainerName is the name of the blob container holding the leases and checkpoints.
+	ContainerName string `mapstructure:"container_name"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Connection == "" {
+		return errMissingConnection
+	}
+	parsed, err := conn.ParsedConnectionFromStr(cfg.Connection)
+	if err != nil {
+		return fmt.Errorf("bad config: invalid connection: %w", err)
+	}
+	if parsed.HubName == "" {
+		return errMissingEntityPath
+	}
+	switch cfg.StartPosition {
+	case StartPositionLatest, StartPositionEarliest:
+	default:
+		return errUnknownStartPosition
+	}
+	if cfg.Checkpoint != nil &&
+		(cfg.Checkpoint.AccountName == "" || cfg.Checkpoint.AccountKey == "" || cfg.Checkpoint.ContainerName == "") {
+		return errIncompleteCheckpoint
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+const testConnection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=c2VjcmV0;EntityPath=insights-logs"
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.Equal(t, 2, len(cfg.Receivers))
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)].(*Config)
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.Connection = testConnection
+	assert.Equal(t, expected, r0)
+
+	r1 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "checkpoint")].(*Config)
+	assert.Equal(t, &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "checkpoint")),
+		Connection:       testConnection,
+		ConsumerGroup:    "otel",
+		StartPosition:    StartPositionEarliest,
+		Checkpoint: &CheckpointSettings{
+			AccountName:   "storageaccount",
+			AccountKey:    "c2VjcmV0",
+			ContainerName: "checkpoints",
+		},
+	}, r1)
+}
+
+func TestLoadConfigError(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		configName  string
+		expectedErr error
+	}{
+		{
+			"missingconnection",
+			errMissingConnection,
+		},
+		{
+			"missingentitypath",
+			errMissingEntityPath,
+		},
+		{
+			"invalidstartposition",
+			errUnknownStartPosition,
+		},
+		{
+			"incompletecheckpoint",
+			errIncompleteCheckpoint,
+		},
+	}
+	for _, tt := range tests {
+		factory := NewFactory()
+		factories.Receivers[typeStr] = factory
+		cfg, _ := configtest.LoadConfig(path.Join(".", "testdata", "config_bad.yaml"), factories)
+		receiver := cfg.Receivers[config.NewComponentIDWithName(typeStr, tt.configName)]
+		require.ErrorIs(t, receiver.Validate(), tt.expectedErr)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"context"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/Azure/azure-event-hubs-go/v3/eph"
+	"github.com/Azure/azure-event-hubs-go/v3/persist"
+	"github.com/Azure/azure-event-hubs-go/v3/storage"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// listener delivers the events of all the partitions of an Event Hub to a handler.
+type listener interface {
+	start(ctx context.Context, handler eventhub.Handler) error
+	close(ctx context.Context) error
+}
+
+// listenerFactory creates the listener of a receiver when it starts.
+type listenerFactory func(cfg *Config) (listener, error)
+
+func newListener(cfg *Config) (listener, error) {
+	if cfg.Checkpoint != nil {
+		return &processorHostListener{config: cfg}, nil
+	}
+	hub, err := eventhub.NewHubFromConnectionString(cfg.Connection)
+	if err != nil {
+		return nil, err
+	}
+	return &hubListener{config: cfg, hub: hub}, nil
+}
+
+// hubListener receives all the partitions of the Event Hub, without checkpointing.
+type hubListener struct {
+	config *Config
+	hub    *eventhub.Hub
+}
+
+func (l *hubListener) start(ctx context.Context, handler eventhub.Handler) error {
+	info, err := l.hub.GetRuntimeInformation(ctx)
+	if err != nil {
+		return err
+	}
+	opts := []eventhub.ReceiveOption{eventhub.ReceiveWithConsumerGroup(l.config.ConsumerGroup)}
+	if l.config.StartPosition == StartPositionEarliest {
+		opts = append(opts, eventhub.ReceiveWithStartingOffset(persist.StartOfStream))
+	} else {
+		opts = append(opts, eventhub.ReceiveWithLatestOffset())
+	}
+	for _, partitionID := range info.PartitionIDs {
+		// The receive loop outlives the start context.
+		if _, err = l.hub.Receive(context.Background(), partitionID, handler, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *hubListener) close(ctx context.Context) error {
+	return l.hub.Close(ctx)
+}
+
+// processorHostListener balances the partitions of the Event Hub between the collector instances
+// sharing the storage container, and checkpoints the handled events.
+type processorHostListener struct {
+	config *Config
+	host   *eph.EventProcessorHost
+}
+
+func (l *processorHostListener) start(ctx context.Context, handler eventhub.Handler) error {
+	credential, err := azblob.NewSharedKeyCredential(l.config.Checkpoint.AccountName, l.config.Checkpoint.AccountKey)
+	if err != nil {
+		return err
+	}
+	leaserCheckpointer, err := storage.NewStorageLeaserCheckpointer(credential, l.config.Checkpoint.AccountName,
+		l.config.Checkpoint.ContainerName, azure.PublicCloud)
+	if err != nil {
+		return err
+	}
+	l.host, err = eph.NewFromConnectionString(ctx, l.config.Connection, leaserCheckpointer, leaserCheckpointer,
+		eph.WithConsumerGroup(l.config.ConsumerGroup), eph.WithNoBanner())
+	if err != nil {
+		return err
+	}
+	if _, err = l.host.RegisterHandler(ctx, handler); err != nil {
+		return err
+	}
+	return l.host.StartNonBlocking(context.Background())
+}
+
+func (l *processorHostListener) close(ctx context.Context) error {
+	if l.host == nil {
+		return nil
+	}
+	return l.host.Close(ctx)
+}
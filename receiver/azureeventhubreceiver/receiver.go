@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"context"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const (
+	transport = "amqp"
+	format    = "azure"
+)
+
+// eventHubReceiver consumes the Azure resource logs and metrics of an Event Hub.
+type eventHubReceiver struct {
+	config          *Config
+	settings        component.ReceiverCreateSettings
+	logsConsumer    consumer.Logs
+	metricsConsumer consumer.Metrics
+	obsrecv         *obsreport.Receiver
+
+	newListener listenerFactory
+	listener    listener
+}
+
+var _ component.Receiver = (*eventHubReceiver)(nil)
+
+func newEventHubReceiver(cfg *Config, set component.ReceiverCreateSettings, newListener listenerFactory) *eventHubReceiver {
+	return &eventHubReceiver{
+		config:   cfg,
+		settings: set,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              transport,
+			ReceiverCreateSettings: set,
+		}),
+		newListener: newListener,
+	}
+}
+
+func (r *eventHubReceiver) Start(ctx context.Context, _ component.Host) error {
+	l, err := r.newListener(r.config)
+	if err != nil {
+		return err
+	}
+	r.listener = l
+	return l.start(ctx, r.handle)
+}
+
+func (r *eventHubReceiver) Shutdown(ctx context.Context) error {
+	if r.listener == nil {
+		return nil
+	}
+	return r.listener.close(ctx)
+}
+
+// handle converts an event to logs and metrics and passes them to the next consumers. Events
+// that cannot be decoded are dropped.
+func (r *eventHubReceiver) handle(ctx context.Context, event *eventhub.Event) error {
+	logs, metrics, err := unmarshalRecords(event.Data)
+	if err != nil {
+		r.settings.Logger.Error("Failed to decode event", zap.String("id", event.ID), zap.Error(err))
+		return nil
+	}
+
+	if count := logs.LogRecordCount(); count > 0 && r.logsConsumer != nil {
+		logsCtx := r.obsrecv.StartLogsOp(ctx)
+		err = r.logsConsumer.ConsumeLogs(logsCtx, logs)
+		r.obsrecv.EndLogsOp(logsCtx, format, count, err)
+		if err != nil {
+			return err
+		}
+	}
+
+	if count := metrics.DataPointCount(); count > 0 && r.metricsConsumer != nil {
+		metricsCtx := r.obsrecv.StartMetricsOp(ctx)
+		err = r.metricsConsumer.ConsumeMetrics(metricsCtx, metrics)
+		r.obsrecv.EndMetricsOp(metricsCtx, format, count, err)
+	}
+	return err
+}
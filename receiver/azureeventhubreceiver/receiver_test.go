@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	eventhub "github.com/Azure/azure-event-hubs-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+// fakeListener delivers the events pushed to it to the handler registered by the receiver.
+type fakeListener struct {
+	handler eventhub.Handler
+	closed  bool
+}
+
+func (l *fakeListener) start(_ context.Context, handler eventhub.Handler) error {
+	l.handler = handler
+	return nil
+}
+
+func (l *fakeListener) close(context.Context) error {
+	l.closed = true
+	return nil
+}
+
+func newTestReceiver() (*eventHubReceiver, *fakeListener) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Connection = testConnection
+	l := &fakeListener{}
+	r := newEventHubReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), func(*Config) (listener, error) {
+		return l, nil
+	})
+	return r, l
+}
+
+func TestReceiverHandlesEvents(t *testing.T) {
+	r, l := newTestReceiver()
+	logsSink := new(consumertest.LogsSink)
+	metricsSink := new(consumertest.MetricsSink)
+	r.logsConsumer = logsSink
+	r.metricsConsumer = metricsSink
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	data, err := os.ReadFile(path.Join(".", "testdata", "records.json"))
+	require.NoError(t, err)
+	require.NoError(t, l.handler(context.Background(), &eventhub.Event{Data: data}))
+
+	assert.Equal(t, 2, logsSink.LogRecordCount())
+	assert.Equal(t, 1, metricsSink.DataPointCount())
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.True(t, l.closed)
+}
+
+func TestReceiverDropsInvalidEvents(t *testing.T) {
+	r, l := newTestReceiver()
+	logsSink := new(consumertest.LogsSink)
+	r.logsConsumer = logsSink
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, l.handler(context.Background(), &eventhub.Event{Data: []byte("not json")}))
+	assert.Equal(t, 0, logsSink.LogRecordCount())
+}
+
+func TestReceiverReturnsConsumerErrors(t *testing.T) {
+	r, l := newTestReceiver()
+	r.logsConsumer = consumertest.NewErr(errors.New("refused"))
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	data, err := os.ReadFile(path.Join(".", "testdata", "records.json"))
+	require.NoError(t, err)
+	assert.EqualError(t, l.handler(context.Background(), &eventhub.Event{Data: data}), "refused")
+}
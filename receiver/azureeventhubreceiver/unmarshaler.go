@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+// Attributes set from the fields of Azure resource logs and metrics.
+const (
+	attributeAzureResourceID      = "azure.resource.id"
+	attributeAzureCategory        = "azure.category"
+	attributeAzureOperationName   = "azure.operation.name"
+	attributeAzureResultType      = "azure.result.type"
+	attributeAzureResultSignature = "azure.result.signature"
+	attributeAzureDuration        = "azure.duration_ms"
+	attributeAzureCorrelationID   = "azure.correlation.id"
+	attributeAzureIdentity        = "azure.identity"
+	attributeAzureLocation        = "azure.location"
+)
+
+// timeGrainPattern matches the ISO 8601 durations used as time grain of Azure metrics, e.g. PT1M.
+var timeGrainPattern = regexp.MustCompile(`^PT(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?$`)
+
+// azureRecords is the envelope of the Azure resource logs and metrics exported to Event Hubs.
+// See https://docs.microsoft.com/en-us/azure/azure-monitor/essentials/resource-logs-schema
+type azureRecords struct {
+	Records []azureRecord `json:"records"`
+}
+
+// azureRecord holds the fields of both the resource log and the metric schemas.
+type azureRecord struct {
+	Time       string `json:"time"`
+	ResourceID string `json:"resourceId"`
+
+	OperationName   string      `json:"operationName"`
+	Category        string      `json:"category"`
+	ResultType      string      `json:"resultType"`
+	ResultSignature string      `json:"resultSignature"`
+	DurationMs      interface{} `json:"durationMs"`
+	CallerIPAddress string      `json:"callerIpAddress"`
+	CorrelationID   string      `json:"correlationId"`
+	Identity        interface{} `json:"identity"`
+	Level           interface{} `json:"level"`
+	Location        string      `json:"location"`
+	Properties      interface{} `json:"properties"`
+
+	MetricName string   `json:"metricName"`
+	TimeGrain  string   `json:"timeGrain"`
+	Count      *float64 `json:"count"`
+	Total      *float64 `json:"total"`
+	Minimum    *float64 `json:"minimum"`
+	Maximum    *float64 `json:"maximum"`
+}
+
+// unmarshalRecords converts an Event Hub message holding Azure resource logs and metrics to logs
+// and metrics. Records with a metric name are converted to metrics, others to logs.
+func unmarshalRecords(data []byte) (pdata.Logs, pdata.Metrics, error) {
+	logs, metrics := pdata.NewLogs(), pdata.NewMetrics()
+
+	var records azureRecords
+	if err := json.Unmarshal(data, &records); err != nil {
+		return logs, metrics, fmt.Errorf("failed to unmarshal Azure records: %w", err)
+	}
+
+	logsByResource := map[string]pdata.LogSlice{}
+	metricsByResource := map[string]pdata.MetricSlice{}
+	for i := range records.Records {
+		record := &records.Records[i]
+		timestamp, err := time.Parse(time.RFC3339Nano, record.Time)
+		if err != nil {
+			return logs, metrics, fmt.Errorf("invalid time %q: %w", record.Time, err)
+		}
+
+		if record.MetricName != "" {
+			slice, ok := metricsByResource[record.ResourceID]
+			if !ok {
+				rm := metrics.ResourceMetrics().AppendEmpty()
+				setResourceAttributes(rm.Resource(), record.ResourceID)
+				slice = rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+				metricsByResource[record.ResourceID] = slice
+			}
+			convertMetric(record, timestamp, slice.AppendEmpty())
+			continue
+		}
+
+		slice, ok := logsByResource[record.ResourceID]
+		if !ok {
+			rl := logs.ResourceLogs().AppendEmpty()
+			setResourceAttributes(rl.Resource(), record.ResourceID)
+			slice = rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+			logsByResource[record.ResourceID] = slice
+		}
+		convertLog(record, timestamp, slice.AppendEmpty())
+	}
+	return logs, metrics, nil
+}
+
+func setResourceAttributes(resource pdata.Resource, resourceID string) {
+	attrs := resource.Attributes()
+	attrs.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAzure)
+	if resourceID != "" {
+		attrs.InsertString(attributeAzureResourceID, resourceID)
+	}
+}
+
+func convertLog(record *azureRecord, timestamp time.Time, lr pdata.LogRecord) {
+	lr.SetTimestamp(pdata.NewTimestampFromTime(timestamp))
+	lr.SetName(record.OperationName)
+
+	if record.Level != nil {
+		level := fmt.Sprintf("%v", record.Level)
+		lr.SetSeverityText(level)
+		lr.SetSeverityNumber(severityNumber(level))
+	}
+
+	attrs := lr.Attributes()
+	insertNonEmpty(attrs, attributeAzureCategory, record.Category)
+	insertNonEmpty(attrs, attributeAzureOperationName, record.OperationName)
+	insertNonEmpty(attrs, attributeAzureResultType, record.ResultType)
+	insertNonEmpty(attrs, attributeAzureResultSignature, record.ResultSignature)
+	insertNonEmpty(attrs, conventions.AttributeNetPeerIP, record.CallerIPAddress)
+	insertNonEmpty(attrs, attributeAzureCorrelationID, record.CorrelationID)
+	insertNonEmpty(attrs, attributeAzureLocation, record.Location)
+	if duration, ok := parseDuration(record.DurationMs); ok {
+		attrs.InsertInt(attributeAzureDuration, duration)
+	}
+	if record.Identity != nil {
+		attrs.Insert(attributeAzureIdentity, parseToAttributeValue(record.Identity))
+	}
+
+	if record.Properties != nil {
+		parseToAttributeValue(record.Properties).CopyTo(lr.Body())
+	}
+}
+
+// convertMetric converts an aggregated Azure metric to a summary, with the minimum and maximum
+// as the 0 and 1 quantiles.
+func convertMetric(record *azureRecord, timestamp time.Time, metric pdata.Metric) {
+	metric.SetName(record.MetricName)
+	metric.SetDataType(pdata.MetricDataTypeSummary)
+
+	dp := metric.Summary().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(timestamp))
+	if grain, ok := parseTimeGrain(record.TimeGrain); ok {
+		dp.SetStartTimestamp(pdata.NewTimestampFromTime(timestamp.Add(-grain)))
+	}
+	if record.Count != nil {
+		dp.SetCount(uint64(*record.Count))
+	}
+	if record.Total != nil {
+		dp.SetSum(*record.Total)
+	}
+	if record.Minimum != nil {
+		quantile := dp.QuantileValues().AppendEmpty()
+		quantile.SetQuantile(0)
+		quantile.SetValue(*record.Minimum)
+	}
+	if record.Maximum != nil {
+		quantile := dp.QuantileValues().AppendEmpty()
+		quantile.SetQuantile(1)
+		quantile.SetValue(*record.Maximum)
+	}
+}
+
+func insertNonEmpty(attrs pdata.AttributeMap, key string, value string) {
+	if value != "" {
+		attrs.InsertString(key, value)
+	}
+}
+
+func severityNumber(level string) pdata.SeverityNumber {
+	switch level {
+	case "Critical":
+		return pdata.SeverityNumberFATAL
+	case "Error":
+		return pdata.SeverityNumberERROR
+	case "Warning":
+		return pdata.SeverityNumberWARN
+	case "Informational", "Information":
+		return pdata.SeverityNumberINFO
+	case "Verbose":
+		return pdata.SeverityNumberDEBUG
+	}
+	return pdata.SeverityNumberUNDEFINED
+}
+
+// parseDuration reads durationMs, which some Azure services report as a string.
+func parseDuration(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return int64(v), true
+	case string:
+		duration, err := strconv.ParseInt(v, 10, 64)
+		return duration, err == nil
+	}
+	return 0, false
+}
+
+func parseTimeGrain(grain string) (time.Duration, bool) {
+	matches := timeGrainPattern.FindStringSubmatch(grain)
+	if matches == nil || grain == "PT" {
+		return 0, false
+	}
+	var duration time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if matches[i+1] == "" {
+			continue
+		}
+		value, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return 0, false
+		}
+		duration += time.Duration(value) * unit
+	}
+	return duration, true
+}
+
+// parseToAttributeValue converts a decoded JSON value to an AttributeValue.
+func parseToAttributeValue(value interface{}) pdata.AttributeValue {
+	switch v := value.(type) {
+	case string:
+		return pdata.NewAttributeValueString(v)
+	case bool:
+		return pdata.NewAttributeValueBool(v)
+	case float64:
+		return pdata.NewAttributeValueDouble(v)
+	case map[string]interface{}:
+		av := pdata.NewAttributeValueMap()
+		am := av.MapVal()
+		am.EnsureCapacity(len(v))
+		for key, nested := range v {
+			am.Insert(key, parseToAttributeValue(nested))
+		}
+		return av
+	case []interface{}:
+		av := pdata.NewAttributeValueArray()
+		slice := av.SliceVal()
+		slice.EnsureCapacity(len(v))
+		for _, nested := range v {
+			parseToAttributeValue(nested).CopyTo(slice.AppendEmpty())
+		}
+		return av
+	case nil:
+		return pdata.NewAttributeValueEmpty()
+	}
+	return pdata.NewAttributeValueString(fmt.Sprintf("%v", value))
+}
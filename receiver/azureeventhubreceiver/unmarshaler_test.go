@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestUnmarshalRecords(t *testing.T) {
+	data, err := os.ReadFile(path.Join(".", "testdata", "records.json"))
+	require.NoError(t, err)
+
+	logs, metrics, err := unmarshalRecords(data)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, logs.ResourceLogs().Len())
+	rl := logs.ResourceLogs().At(0)
+	resourceID, _ := rl.Resource().Attributes().Get("azure.resource.id")
+	assert.Equal(t, "/SUBSCRIPTIONS/0000/RESOURCEGROUPS/RG/PROVIDERS/MICROSOFT.WEB/SITES/APP", resourceID.StringVal())
+	provider, _ := rl.Resource().Attributes().Get("cloud.provider")
+	assert.Equal(t, "azure", provider.StringVal())
+
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.NewTimestampFromTime(time.Date(2021, 11, 30, 10, 0, 0, 123456700, time.UTC)), lr.Timestamp())
+	assert.Equal(t, "Microsoft.Web/sites/log", lr.Name())
+	assert.Equal(t, "Informational", lr.SeverityText())
+	assert.Equal(t, pdata.SeverityNumberINFO, lr.SeverityNumber())
+	assert.Equal(t, pdata.NewAttributeMapFromMap(map[string]pdata.AttributeValue{
+		"azure.category":         pdata.NewAttributeValueString("AppServiceHTTPLogs"),
+		"azure.operation.name":   pdata.NewAttributeValueString("Microsoft.Web/sites/log"),
+		"azure.result.type":      pdata.NewAttributeValueString("Success"),
+		"azure.result.signature": pdata.NewAttributeValueString("200"),
+		"azure.duration_ms":      pdata.NewAttributeValueInt(42),
+		"net.peer.ip":            pdata.NewAttributeValueString("10.0.0.1"),
+		"azure.correlation.id":   pdata.NewAttributeValueString("b7c5e0b4"),
+		"azure.location":         pdata.NewAttributeValueString("westeurope"),
+	}).Sort(), lr.Attributes().Sort())
+	assert.Equal(t, pdata.AttributeValueTypeMap, lr.Body().Type())
+	status, _ := lr.Body().MapVal().Get("ScStatus")
+	assert.Equal(t, 200.0, status.DoubleVal())
+
+	lr = logs.ResourceLogs().At(1).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.SeverityNumberERROR, lr.SeverityNumber())
+	duration, _ := lr.Attributes().Get("azure.duration_ms")
+	assert.Equal(t, int64(7), duration.IntVal())
+	identity, _ := lr.Attributes().Get("azure.identity")
+	assert.Equal(t, pdata.AttributeValueTypeMap, identity.Type())
+
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	metric := metrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "Requests", metric.Name())
+	require.Equal(t, pdata.MetricDataTypeSummary, metric.DataType())
+	dp := metric.Summary().DataPoints().At(0)
+	assert.Equal(t, pdata.NewTimestampFromTime(time.Date(2021, 11, 30, 10, 1, 0, 0, time.UTC)), dp.Timestamp())
+	assert.Equal(t, pdata.NewTimestampFromTime(time.Date(2021, 11, 30, 10, 0, 0, 0, time.UTC)), dp.StartTimestamp())
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, 12.0, dp.Sum())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 2.0, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 1.0, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 6.0, dp.QuantileValues().At(1).Value())
+}
+
+func TestUnmarshalRecordsInvalid(t *testing.T) {
+	_, _, err := unmarshalRecords([]byte("not json"))
+	assert.Error(t, err)
+
+	_, _, err = unmarshalRecords([]byte(`{"records":[{"time":"yesterday"}]}`))
+	assert.EqualError(t, err, `invalid time "yesterday": parsing time "yesterday" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "yesterday" as "2006"`)
+}
+
+func TestParseTimeGrain(t *testing.T) {
+	tests := []struct {
+		grain    string
+		duration time.Duration
+		ok       bool
+	}{
+		{"PT1M", time.Minute, true},
+		{"PT1H", time.Hour, true},
+		{"PT1H30M15S", time.Hour + 30*time.Minute + 15*time.Second, true},
+		{"PT", 0, false},
+		{"P1D", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		duration, ok := parseTimeGrain(tt.grain)
+		assert.Equal(t, tt.ok, ok, tt.grain)
+		assert.Equal(t, tt.duration, duration, tt.grain)
+	}
+}